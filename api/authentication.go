@@ -0,0 +1,34 @@
+package api
+
+import (
+	"coinche/usecases"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const playerNameKey = "playerName"
+
+// getToken reads the bearer token, or the token query parameter since browsers cannot set headers on websockets.
+func getToken(context *gin.Context) string {
+	header := context.GetHeader("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+
+	return context.Query("token")
+}
+
+func authenticate(userUsecases *usecases.UserUsecases) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		playerName, err := userUsecases.Authenticate(getToken(context))
+		if err != nil {
+			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		context.Set(playerNameKey, playerName)
+		context.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// botDelay lets the players see each move of the bots.
+var botDelay = time.Second
+
+type botTurns struct {
+	mu      sync.Mutex
+	playing map[int]bool
+}
+
+func (turns *botTurns) start(gameID int) bool {
+	turns.mu.Lock()
+	defer turns.mu.Unlock()
+	if turns.playing[gameID] {
+		return false
+	}
+	turns.playing[gameID] = true
+	return true
+}
+
+func (turns *botTurns) stop(gameID int) {
+	turns.mu.Lock()
+	defer turns.mu.Unlock()
+	delete(turns.playing, gameID)
+}
+
+// playBot runs outside of the hub loop because the bot move is broadcast through the hub.
+// Only one bot plays at a time in a game.
+func playBot(h *Hub, gameID int, botName string) {
+	if !h.bots.start(gameID) {
+		return
+	}
+
+	time.Sleep(botDelay)
+
+	hasRedealt, err := h.gameUsecases.PlayBot(gameID, botName)
+	if err != nil {
+		h.bots.stop(gameID)
+		fmt.Println("Bot ", botName, " could not play: ", err)
+		return
+	}
+
+	game, err := h.gameUsecases.GetGame(gameID)
+	h.bots.stop(gameID)
+	if err != nil {
+		fmt.Println("Could not get game after bot move: ", err)
+		return
+	}
+
+	if hasRedealt {
+		broadcastMessage(redealMessage, gameID, h)
+	}
+	broadcastGame(game, h)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"coinche/domain"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	ErrTooManyMessages = "TOO MANY MESSAGES"
+)
+
+const (
+	CHAT_RATE_LIMIT  = 5
+	CHAT_RATE_WINDOW = 10 * time.Second
+)
+
+// chatLimiter lets each player send CHAT_RATE_LIMIT messages or reactions per CHAT_RATE_WINDOW in a game.
+type chatLimiter struct {
+	mu    sync.Mutex
+	clock Clock
+	sent  map[int]map[string][]time.Time
+}
+
+func (limiter *chatLimiter) configure(clock Clock) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.clock = clock
+}
+
+func (limiter *chatLimiter) allow(gameID int, name string) bool {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.clock.Now()
+	if limiter.sent[gameID] == nil {
+		limiter.sent[gameID] = make(map[string][]time.Time)
+	}
+
+	recent := []time.Time{}
+	for _, sentAt := range limiter.sent[gameID][name] {
+		if now.Sub(sentAt) < CHAT_RATE_WINDOW {
+			recent = append(recent, sentAt)
+		}
+	}
+
+	if len(recent) >= CHAT_RATE_LIMIT {
+		limiter.sent[gameID][name] = recent
+		return false
+	}
+
+	limiter.sent[gameID][name] = append(recent, now)
+	return true
+}
+
+func (limiter *chatLimiter) forget(gameID int) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	delete(limiter.sent, gameID)
+}
+
+type chatEnvelope struct {
+	Chat domain.ChatMessage
+}
+
+type chatHistory struct {
+	ChatHistory []domain.ChatMessage
+}
+
+func broadcastChat(chat domain.ChatMessage, gameID int, hub *Hub) {
+	fmt.Println("S >>> broadcasting chat from:", chat.Sender)
+	r := reply{kind: ChatReply, payload: chat, legacy: chatEnvelope{Chat: chat}}
+	hub.broadcast <- message{reply: r, gameID: gameID}
+}
+
+// sendChatHistory lets late joiners see the recent messages, nothing is sent when the chat is empty.
+func sendChatHistory(p *player, gameID int) {
+	messages, err := p.hub.gameUsecases.GetChatHistory(gameID)
+	if err != nil {
+		fmt.Println("Could not get chat history: ", err)
+		return
+	}
+
+	if len(messages) == 0 {
+		return
+	}
+
+	r := reply{kind: ChatHistoryReply, payload: messages, legacy: chatHistory{ChatHistory: messages}}
+	p.hub.single <- private{player: p, reply: r, gameID: gameID}
+}
+
+func (s *socketHandler) chat(content string) error {
+	if !s.player.hub.chats.allow(s.gameID, s.playerName) {
+		return fmt.Errorf("Could not chat: %w", errors.New(ErrTooManyMessages))
+	}
+
+	chat, err := s.gameUsecases.Chat(s.gameID, s.playerName, content)
+	if err != nil {
+		return fmt.Errorf("Could not chat: %w", err)
+	}
+
+	broadcastChat(chat, s.gameID, s.player.hub)
+	return nil
+}
+
+func (s *socketHandler) react(content string) error {
+	if !s.player.hub.chats.allow(s.gameID, s.playerName) {
+		return fmt.Errorf("Could not react: %w", errors.New(ErrTooManyMessages))
+	}
+
+	chat, err := s.gameUsecases.React(s.gameID, s.playerName, content)
+	if err != nil {
+		return fmt.Errorf("Could not react: %w", err)
+	}
+
+	broadcastChat(chat, s.gameID, s.player.hub)
+	return nil
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"coinche/domain"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,7 +11,32 @@ import (
 func (gameAPIs *GameAPIs) CreateGame(context *gin.Context) {
 	name := context.Query("name")
 
-	gameID, err := gameAPIs.Usecases.CreateGame(name)
+	target := 0
+	stringTarget := context.Query("target")
+	if stringTarget != "" {
+		var err error
+		target, err = strconv.Atoi(stringTarget)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG TARGET FORMAT"})
+			return
+		}
+	}
+
+	var seed int64
+	stringSeed := context.Query("seed")
+	if stringSeed != "" {
+		var err error
+		seed, err = strconv.ParseInt(stringSeed, 10, 64)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG SEED FORMAT"})
+			return
+		}
+	}
+
+	rules := context.Query("rules")
+	dealing := domain.DealingPattern(context.Query("dealing"))
+
+	gameID, err := gameAPIs.Usecases.CreateGame(name, target, rules, dealing, seed)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -3,6 +3,7 @@ package api
 import (
 	"coinche/domain"
 	"coinche/usecases"
+	testUtilities "coinche/utilities/test"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -27,10 +28,12 @@ func TestDeletGame(test *testing.T) {
 		},
 	)
 	gameUsecases := usecases.NewGameUsecases(&mockRepository)
-	router, _ := SetupRouter(gameUsecases, []string{})
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
 
 	test.Run("leave game", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodDelete, "/games/1/delete", nil)
+		testUtilities.Authorize(request, newTokenOrFatal(test, userUsecases, "P1"))
 		response := httptest.NewRecorder()
 		router.ServeHTTP(response, request)
 		assert.Equal(http.StatusAccepted, response.Code)
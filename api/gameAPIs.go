@@ -1,7 +1,33 @@
 package api
 
-import "coinche/usecases"
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
 
 type GameAPIs struct {
 	Usecases *usecases.GameUsecases
+	// Tournaments is nil when the games are not run in tournaments.
+	Tournaments *usecases.TournamentUsecases
+}
+
+// checkGameVisible refuses to show a game of a tournament while other tables of its round still play it.
+func (gameAPIs *GameAPIs) checkGameVisible(context *gin.Context, gameID int) bool {
+	if gameAPIs.Tournaments == nil {
+		return true
+	}
+
+	err := gameAPIs.Tournaments.CheckGameVisible(gameID)
+	if err != nil && err.Error() == domain.ErrRoundNotFinished {
+		context.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return false
+	}
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
 }
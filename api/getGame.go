@@ -14,11 +14,15 @@ func (gameAPIs *GameAPIs) GetGame(context *gin.Context) {
 		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG ID FORMAT"})
 		return
 	}
+	if !gameAPIs.checkGameVisible(context, gameID) {
+		return
+	}
+
 	game, err := gameAPIs.Usecases.GetGame(gameID)
 	if err != nil {
 		context.JSON(http.StatusNotFound, gin.H{"error": "GAME NOT FOUND"})
 		return
 	}
 
-	context.JSON(http.StatusOK, game)
+	context.JSON(http.StatusOK, game.ViewFor(""))
 }
@@ -17,7 +17,7 @@ func TestGetGame(test *testing.T) {
 		map[int]domain.Game{
 			1: {Name: "GAME ONE"},
 			2: {Name: "GAME TWO", Players: map[string]domain.Player{
-				"P1": {},
+				"P1": {Hand: []domain.CardID{domain.C7}},
 				"P2": {},
 				"P3": {},
 				"P4": {},
@@ -25,10 +25,11 @@ func TestGetGame(test *testing.T) {
 		},
 	)
 	gameUsecases := usecases.NewGameUsecases(&mockRepository)
-	router, _ := SetupRouter(gameUsecases, []string{})
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
 
 	test.Run("get a game 1", func(test *testing.T) {
-		want := domain.Game(domain.Game{ID: 1, Root: 1, Name: "GAME ONE"})
+		want := domain.Game(domain.Game{ID: 1, Root: 1, Name: "GAME ONE", Players: map[string]domain.Player{}})
 
 		request := testUtilities.NewGetGameRequest(test, 1)
 		response := httptest.NewRecorder()
@@ -40,6 +41,18 @@ func TestGetGame(test *testing.T) {
 		assert.Equal(want, got)
 	})
 
+	test.Run("does not expose the hands", func(test *testing.T) {
+		request := testUtilities.NewGetGameRequest(test, 2)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		got := testUtilities.DecodeToGame(response.Body, test)
+
+		assert.Equal(http.StatusOK, response.Code)
+		assert.Equal(4, len(got.Players))
+		assert.Nil(got.Players["P1"].Hand)
+	})
+
 	test.Run("returns 404 on missing game", func(t *testing.T) {
 		request := testUtilities.NewGetGameRequest(test, 3)
 		response := httptest.NewRecorder()
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"coinche/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gameAPIs *GameAPIs) GetReplay(context *gin.Context) {
+	stringID := context.Param("id")
+	gameID, err := strconv.Atoi(stringID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG ID FORMAT"})
+		return
+	}
+
+	if !gameAPIs.checkGameVisible(context, gameID) {
+		return
+	}
+
+	timeline, err := gameAPIs.Usecases.GetTimeline(gameID)
+	if err != nil && err.Error() == domain.ErrDealNotFinished {
+		context.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		context.JSON(http.StatusNotFound, gin.H{"error": "GAME NOT FOUND"})
+		return
+	}
+
+	context.JSON(http.StatusOK, timeline)
+}
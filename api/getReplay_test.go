@@ -0,0 +1,103 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	testUtilities "coinche/utilities/test"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReplay(test *testing.T) {
+	assert := assert.New(test)
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{
+			1: {Name: "GAME ONE", Phase: domain.Bidding},
+			2: {
+				Name:  "GAME ONE",
+				Phase: domain.Counting,
+				Seed:  42,
+				Players: map[string]domain.Player{
+					"P1": {Team: "odd"},
+					"P2": {Team: "even"},
+					"P3": {Team: "odd"},
+					"P4": {Team: "even"},
+				},
+				Bids: []domain.BidAction{
+					{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Heart},
+					{Sequence: 2, Kind: domain.PassAction, Player: "P2"},
+					{Sequence: 3, Kind: domain.PassAction, Player: "P3"},
+					{Sequence: 4, Kind: domain.PassAction, Player: "P4"},
+					{Sequence: 5, Kind: domain.PassAction, Player: "P1"},
+				},
+				Turns: []domain.Turn{
+					{Plays: []domain.Play{
+						{PlayerName: "P1", Card: domain.C7},
+						{PlayerName: "P2", Card: domain.C10},
+						{PlayerName: "P3", Card: domain.CK},
+						{PlayerName: "P4", Card: domain.H9},
+					}, Winner: "P4"},
+				},
+				Points: map[string]int{"odd": 0, "even": 162},
+				Scores: map[string]int{"odd": 160, "even": 0},
+			},
+		},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
+
+	test.Run("get the timeline of a finished deal", func(test *testing.T) {
+		request := testUtilities.NewGetReplayRequest(test, 2)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		var got domain.Timeline
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(http.StatusOK, response.Code)
+		assert.Equal(2, got.GameID)
+		assert.Equal(11, len(got.Steps))
+		assert.Equal([]domain.CardID{domain.C7}, got.Steps[0].Hands["P1"])
+		assert.Equal(domain.PassStep, got.Steps[2].Kind)
+		assert.Equal("P2", got.Steps[2].Player)
+		assert.Equal("P4", got.Steps[9].TrickWinner)
+		assert.Equal(map[string]int{"odd": 160, "even": 0}, got.Steps[10].Scores)
+	})
+
+	test.Run("not give the seed of the game", func(test *testing.T) {
+		request := testUtilities.NewGetReplayRequest(test, 2)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusOK, response.Code)
+		assert.NotContains(response.Body.String(), "Seed")
+	})
+
+	test.Run("returns 409 on a deal in progress", func(test *testing.T) {
+		request := testUtilities.NewGetReplayRequest(test, 1)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusConflict, response.Code)
+	})
+
+	test.Run("returns 404 on missing game", func(test *testing.T) {
+		request := testUtilities.NewGetReplayRequest(test, 3)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusNotFound, response.Code)
+	})
+}
@@ -13,6 +13,7 @@ import (
 var wsupgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	Subprotocols:    []string{PROTOCOL_NAME},
 	CheckOrigin: func(r *http.Request) bool {
 		connectionOrigin := r.Header.Get("Origin")
 		fmt.Println(connectionOrigin)
@@ -33,7 +34,7 @@ func (gameAPIs *GameAPIs) JoinGame(context *gin.Context, hub *Hub) {
 		return
 	}
 
-	playerName := context.Query("playerName")
+	playerName := context.GetString(playerNameKey)
 
 	connection, err := wsupgrader.Upgrade(context.Writer, context.Request, nil)
 	if err != nil {
@@ -16,7 +16,7 @@ func (gameAPIs *GameAPIs) leaveGame(context *gin.Context) {
 		return
 	}
 
-	playerName := context.Query("playerName")
+	playerName := context.GetString(playerNameKey)
 
 	err = gameAPIs.Usecases.LeaveGame(gameID, playerName)
 	fmt.Println(err)
@@ -28,10 +28,12 @@ func TestLeaveGame(test *testing.T) {
 		},
 	)
 	gameUsecases := usecases.NewGameUsecases(&mockRepository)
-	router, _ := SetupRouter(gameUsecases, []string{})
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
 
 	test.Run("leave game", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPut, "/games/1/leave?playerName=P1", nil)
+		request, _ := http.NewRequest(http.MethodPut, "/games/1/leave", nil)
+		testUtilities.Authorize(request, newTokenOrFatal(test, userUsecases, "P1"))
 		response := httptest.NewRecorder()
 		router.ServeHTTP(response, request)
 		assert.Equal(http.StatusAccepted, response.Code)
@@ -38,7 +38,8 @@ func TestListGames(test *testing.T) {
 		},
 	)
 	gameUsecases := usecases.NewGameUsecases(&mockRepository)
-	router, _ := SetupRouter(gameUsecases, []string{})
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
 
 	test.Run("list games", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/games/all", nil)
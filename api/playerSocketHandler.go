@@ -3,13 +3,14 @@ package api
 import (
 	"coinche/domain"
 	"coinche/usecases"
+	"errors"
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/gorilla/websocket"
 )
 
+const redealMessage = "redeal"
+
 var (
 	cards = map[string]domain.CardID{
 		"7-club":        domain.C7,
@@ -47,29 +48,64 @@ var (
 	}
 )
 
-func joinGame(connection *websocket.Conn, usecases *usecases.GameUsecases, gameID int, playerName string) domain.Game {
+func joinGame(connection *websocket.Conn, usecases *usecases.GameUsecases, gameID int, playerName string) (domain.Game, error) {
 	game, err := usecases.JoinGame(gameID, playerName)
 	if err != nil {
-		err := SendMessage(connection, fmt.Sprint("Could not join this game: ", err), "S")
+		closeWithError(connection, "Could not join this game: ", err)
+		return domain.Game{}, err
+	}
+
+	return game, nil
+}
+
+func closeWithError(connection *websocket.Conn, message string, err error) {
+	fmt.Println("S > sending message:", message, err)
+	err = sendReply(connection, newErrorReply("", fmt.Errorf("%s%w", message, err)))
+	if err != nil {
+		fmt.Println("Error sending closing error: ", err)
+	}
+	connection.Close()
+}
+
+func newPlayer(gameID int, connection *websocket.Conn, game domain.Game, hub *Hub, playerName string) *player {
+	p := &player{hub: hub, connection: connection, send: make(chan []byte, 256), name: playerName}
+
+	if _, ok := game.Players[playerName]; ok {
+		session, err := hub.userUsecases.IssueSessionToken(gameID, playerName)
 		if err != nil {
-			fmt.Println("Error sending closing error in « Join game: ", err)
+			fmt.Println("Could not issue session token: ", err)
 		}
-		connection.Close()
-		return domain.Game{}
+		p.session = session
 	}
 
-	return game
+	return p
 }
 
-func subscribeAndBroadcast(gameID int, connection *websocket.Conn, game domain.Game, hub *Hub) *player {
-	p := &player{hub: hub, connection: connection, send: make(chan []byte, 256)}
+func subscribeAndBroadcast(gameID int, connection *websocket.Conn, game domain.Game, hub *Hub, playerName string) *player {
+	p := newPlayer(gameID, connection, game, hub, playerName)
 	p.hub.register <- subscription{player: p, gameID: gameID}
 
 	broadcastGame(game, p.hub)
+	sendChatHistory(p, gameID)
 
 	return p
 }
 
+type missedEvents struct {
+	MissedEvents []domain.Event
+}
+
+func sendMissedEvents(p *player, gameID int, since int) {
+	events, err := p.hub.gameUsecases.GetEventsSince(gameID, since, p.name)
+	if err != nil {
+		fmt.Println("Could not get missed events: ", err)
+		return
+	}
+
+	r := reply{kind: MissedEventsReply, payload: events, legacy: missedEvents{MissedEvents: events}}
+	p.hub.single <- private{player: p, reply: r, gameID: gameID}
+}
+
 type socketHandler struct {
 	gameID       int
 	playerName   string
@@ -77,16 +113,38 @@ type socketHandler struct {
 	player       *player
 }
 
-func (s *socketHandler) SendErrorMessage(message string, err error) {
-	errorMessage := fmt.Sprint(message, err)
+func (s *socketHandler) sendError(id string, err error) {
+	fmt.Println("S > sending error:", err)
 	s.player.mu.Lock()
 	defer s.player.mu.Unlock()
-	err = SendMessage(s.player.connection, errorMessage, "S")
+	err = sendReply(s.player.connection, newErrorReply(id, err))
 	if err != nil {
 		fmt.Println("Error message not sent: ", err)
 	}
 }
 
+// respond goes through the hub, so the ack of a request comes after the views it has broadcast.
+func (s *socketHandler) respond(id string, err error) {
+	if err != nil {
+		s.sendError(id, err)
+		return
+	}
+
+	if id != "" {
+		s.player.hub.single <- private{player: s.player, reply: newAckReply(id), gameID: s.gameID}
+	}
+}
+
+func (s *socketHandler) broadcastUpdatedGame() error {
+	game, err := s.gameUsecases.GetGame(s.gameID)
+	if err != nil {
+		return fmt.Errorf("Could not get updated game: %w", err)
+	}
+
+	broadcastGame(game, s.player.hub)
+	return nil
+}
+
 func (s *socketHandler) leave(game domain.Game) {
 	err := s.gameUsecases.LeaveGame(s.gameID, s.playerName)
 	if err != nil {
@@ -102,122 +160,120 @@ func (s *socketHandler) leave(game domain.Game) {
 	s.player.connection.Close()
 }
 
-func (s *socketHandler) joinTeam(content string) {
-	err := s.gameUsecases.JoinTeam(s.gameID, s.playerName, content)
+func (s *socketHandler) joinTeam(teamName string) error {
+	err := s.gameUsecases.JoinTeam(s.gameID, s.playerName, teamName)
 	if err != nil {
-		s.SendErrorMessage("Could not join team: ", err)
-		return
+		return fmt.Errorf("Could not join team: %w", err)
 	}
 
-	game, err := s.gameUsecases.GetGame(s.gameID)
+	return s.broadcastUpdatedGame()
+}
+
+func (s *socketHandler) startGame() error {
+	err := s.gameUsecases.StartGame(s.gameID)
 	if err != nil {
-		s.SendErrorMessage("Could not get updated game: ", err)
-		return
+		return fmt.Errorf("Could not start game: %w", err)
 	}
 
-	broadcastGame(game, s.player.hub)
+	return s.broadcastUpdatedGame()
 }
 
-func (s socketHandler) startGame(content string) {
-	err := s.gameUsecases.StartGame(s.gameID)
+func (s *socketHandler) pass() error {
+	hasRedealt, err := s.gameUsecases.Pass(s.gameID, s.playerName)
 	if err != nil {
-		s.SendErrorMessage("Could not start game: ", err)
-		return
+		return fmt.Errorf("Could not pass: %w", err)
+	}
+	if hasRedealt {
+		broadcastMessage(redealMessage, s.gameID, s.player.hub)
 	}
 
-	game, err := s.gameUsecases.GetGame(s.gameID)
+	return s.broadcastUpdatedGame()
+}
 
+func (s *socketHandler) cut(position int) error {
+	err := s.gameUsecases.Cut(s.gameID, s.playerName, position)
 	if err != nil {
-		s.SendErrorMessage("Could not get updated game: ", err)
-		return
+		return fmt.Errorf("Could not cut: %w", err)
 	}
 
-	broadcastGame(game, s.player.hub)
+	return s.broadcastUpdatedGame()
 }
 
-func (s socketHandler) bid(content string) {
-	if content == "pass" {
-		err := s.gameUsecases.Pass(s.gameID, s.playerName)
-		if err != nil {
-			s.SendErrorMessage("Could not pass: ", err)
-			return
-		}
-	} else if content == "coinche" {
-		err := s.gameUsecases.Coinche(s.gameID, s.playerName)
-		if err != nil {
-			s.SendErrorMessage("Could not coinche: ", err)
-			return
-		}
-	} else {
-		array := strings.Split(content, ",")
-		if len(array) != 2 {
-			s.player.mu.Lock()
-			defer s.player.mu.Unlock()
-			err := SendMessage(s.player.connection, "Invalid bid", "S")
-			if err != nil {
-				fmt.Println("Error sendind message « Invalid bid » :" + err.Error())
-			}
-			return
-		}
+func (s *socketHandler) coinche() error {
+	err := s.gameUsecases.Coinche(s.gameID, s.playerName)
+	if err != nil {
+		return fmt.Errorf("Could not coinche: %w", err)
+	}
 
-		colorString := array[0]
-		valueString := array[1]
-		valueInt, err := strconv.Atoi(valueString)
-		if err != nil {
-			s.SendErrorMessage("Could not parse bid value:", err)
-			return
-		}
+	return s.broadcastUpdatedGame()
+}
 
-		bidColor := domain.Color(colorString)
-		bidValue := domain.BidValue(valueInt)
+func (s *socketHandler) surcoinche() error {
+	err := s.gameUsecases.Surcoinche(s.gameID, s.playerName)
+	if err != nil {
+		return fmt.Errorf("Could not surcoinche: %w", err)
+	}
 
-		err = s.gameUsecases.Bid(s.gameID, s.playerName, bidValue, bidColor)
-		if err != nil {
-			s.SendErrorMessage("Could not bid: ", err)
-			return
-		}
+	return s.broadcastUpdatedGame()
+}
+
+func (s *socketHandler) bid(value domain.BidValue, color domain.Color) error {
+	err := s.gameUsecases.Bid(s.gameID, s.playerName, value, color)
+	if err != nil {
+		return fmt.Errorf("Could not bid: %w", err)
 	}
 
-	game, err := s.gameUsecases.GetGame(s.gameID)
+	return s.broadcastUpdatedGame()
+}
+
+func (s *socketHandler) play(card domain.CardID) error {
+	if _, ok := cards[string(card)]; !ok {
+		return errors.New(ErrInvalidCard)
+	}
+
+	err := s.gameUsecases.PlayCard(s.gameID, s.playerName, card)
 	if err != nil {
-		s.SendErrorMessage("Could not get updated game: ", err)
-		return
+		return fmt.Errorf("Could not play: %w", err)
 	}
 
-	broadcastGame(game, s.player.hub)
+	return s.broadcastUpdatedGame()
 }
 
-func (s socketHandler) play(content string) {
-	card, ok := cards[content]
-	if !ok {
-		s.player.mu.Lock()
-		defer s.player.mu.Unlock()
-		err := SendMessage(s.player.connection, "Invalid card", "S")
-		if err != nil {
-			fmt.Println("Error sending message « Invalid card » : " + err.Error())
+func (s *socketHandler) declare(declaredCards []domain.CardID) error {
+	for _, card := range declaredCards {
+		if _, ok := cards[string(card)]; !ok {
+			return errors.New(ErrInvalidCard)
 		}
-		return
 	}
 
-	err := s.gameUsecases.PlayCard(s.gameID, s.playerName, card)
+	err := s.gameUsecases.Declare(s.gameID, s.playerName, declaredCards)
 	if err != nil {
-		s.SendErrorMessage("Could not play: ", err)
-		return
+		return fmt.Errorf("Could not declare: %w", err)
+	}
+
+	return s.broadcastUpdatedGame()
+}
+
+func (s *socketHandler) addBot(strategy string, teamName string) error {
+	botName, err := s.gameUsecases.AddBot(s.gameID, strategy, teamName)
+	if err != nil {
+		return fmt.Errorf("Could not add bot: %w", err)
 	}
 
 	game, err := s.gameUsecases.GetGame(s.gameID)
 	if err != nil {
-		s.SendErrorMessage("Could not get updated game: ", err)
-		return
+		return fmt.Errorf("Could not get updated game: %w", err)
 	}
 
+	broadcastMessage(fmt.Sprint(botName, " has joined the game"), game.ID, s.player.hub)
 	broadcastGame(game, s.player.hub)
+	return nil
 }
 
-func (s socketHandler) pong() {
+func (s *socketHandler) pong(id string) {
 	s.player.mu.Lock()
 	defer s.player.mu.Unlock()
-	err := SendMessageWithoutLog(s.player.connection, "pong")
+	err := sendReply(s.player.connection, newPongReply(id))
 	if err != nil {
 		fmt.Println("Error sending pong message: ", err)
 	}
@@ -229,66 +285,106 @@ func PlayerSocketHandler(
 	playerName string,
 	hub *Hub,
 ) {
-	game := joinGame(connection, hub.gameUsecases, gameID, playerName)
-	player := subscribeAndBroadcast(gameID, connection, game, hub)
+	game, err := joinGame(connection, hub.gameUsecases, gameID, playerName)
+	if err != nil {
+		return
+	}
+	player := subscribeAndBroadcast(gameID, connection, game, hub, playerName)
+
+	handleMessages(connection, gameID, playerName, hub, player, game)
+}
+
+// ResumeSocketHandler binds the socket to the existing seat of the player, whatever the phase of the game.
+func ResumeSocketHandler(
+	connection *websocket.Conn,
+	gameID int,
+	playerName string,
+	since int,
+	hub *Hub,
+) {
+	game, err := hub.gameUsecases.Reconnect(gameID, playerName)
+	if err != nil {
+		closeWithError(connection, "Could not reconnect to this game: ", err)
+		return
+	}
+
+	player := newPlayer(gameID, connection, game, hub, playerName)
+	hub.register <- subscription{player: player, gameID: gameID}
+
+	sendMissedEvents(player, gameID, since)
+	broadcastMessage(fmt.Sprint(playerName, " has reconnected"), gameID, hub)
+	broadcastGame(game, hub)
+	sendChatHistory(player, gameID)
+
+	handleMessages(connection, gameID, playerName, hub, player, game)
+}
+
+func handleMessages(
+	connection *websocket.Conn,
+	gameID int,
+	playerName string,
+	hub *Hub,
+	player *player,
+	game domain.Game,
+) {
+	socketHandler := socketHandler{
+		gameID:       gameID,
+		playerName:   playerName,
+		gameUsecases: hub.gameUsecases,
+		player:       player,
+	}
 
 	for {
-		message, err := ReceiveMessage(connection)
+		request, err := receiveRequest(connection)
 		if err != nil {
 			fmt.Println("< Error receiving message: ", err)
-			break
+			if player.session != "" {
+				disconnect(hub, player, gameID)
+			}
+			return
 		}
 
-		array := strings.Split(message, ": ")
-		head := array[0]
-		content := strings.Join(array[1:], "/")
-
-		socketHandler := socketHandler{
-			gameID:       gameID,
-			playerName:   playerName,
-			gameUsecases: hub.gameUsecases,
-			player:       player,
+		if request.err != nil {
+			socketHandler.sendError(request.id, request.err)
+			continue
 		}
 
-		switch head {
+		payload := request.payload
+		switch request.kind {
 		case "leave":
-			{
-				socketHandler.leave(game)
-				break
-			}
+			socketHandler.leave(game)
+			return
 		case "joinTeam":
-			{
-				socketHandler.joinTeam(content)
-				break
-			}
+			err = socketHandler.joinTeam(payload.Team)
 		case "start":
-			{
-				socketHandler.startGame(content)
-				break
-			}
+			err = socketHandler.startGame()
+		case "cut":
+			err = socketHandler.cut(payload.Position)
 		case "bid":
-			{
-				socketHandler.bid(content)
-				break
-			}
+			err = socketHandler.bid(payload.Value, payload.Color)
+		case "pass":
+			err = socketHandler.pass()
+		case "coinche":
+			err = socketHandler.coinche()
+		case "surcoinche":
+			err = socketHandler.surcoinche()
 		case "play":
-			{
-				socketHandler.play(content)
-				break
-			}
+			err = socketHandler.play(payload.Card)
+		case "declare":
+			err = socketHandler.declare(payload.Cards)
+		case "addBot":
+			err = socketHandler.addBot(payload.Strategy, payload.Team)
+		case "chat":
+			err = socketHandler.chat(payload.Text)
+		case "react":
+			err = socketHandler.react(payload.Reaction)
 		case "ping":
-			{
-				socketHandler.pong()
-				break
-			}
+			socketHandler.pong(request.id)
+			continue
 		default:
-			{
-				err = SendMessage(connection, "Message not understood by the server", "S")
-				if err != nil {
-					fmt.Println("Error sending message: « Message not understood by the server » :", err)
-				}
-				break
-			}
+			err = errors.New(ErrUnknownMessage)
 		}
+
+		socketHandler.respond(request.id, err)
 	}
 }
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+)
+
+// presence remembers the last event seen by each disconnected player, so it can be sent what it missed when it
+// reconnects.
+type presence struct {
+	mu            sync.Mutex
+	lastSequences map[int]map[string]int
+}
+
+func (p *presence) disconnect(gameID int, playerName string, sequence int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lastSequences[gameID] == nil {
+		p.lastSequences[gameID] = make(map[string]int)
+	}
+	p.lastSequences[gameID][playerName] = sequence
+}
+
+func (p *presence) reconnect(gameID int, playerName string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sequence := p.lastSequences[gameID][playerName]
+	delete(p.lastSequences[gameID], playerName)
+	if len(p.lastSequences[gameID]) == 0 {
+		delete(p.lastSequences, gameID)
+	}
+	return sequence
+}
+
+func disconnect(h *Hub, player *player, gameID int) {
+	h.unregister <- subscription{player: player, gameID: gameID}
+
+	sequence := 0
+	events, err := h.gameUsecases.GetEvents(gameID)
+	if err != nil {
+		fmt.Println("Could not get events on disconnection: ", err)
+	}
+	if len(events) > 0 {
+		sequence = events[len(events)-1].Sequence
+	}
+	h.presence.disconnect(gameID, player.name, sequence)
+
+	broadcastMessage(fmt.Sprint(player.name, " has disconnected"), gameID, h)
+}
@@ -0,0 +1,295 @@
+package api
+
+import (
+	"coinche/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	ErrUnknownMessage       = "UNKNOWN MESSAGE"
+	ErrMalformedRequest     = "MALFORMED REQUEST"
+	ErrUnsupportedVersion   = "UNSUPPORTED VERSION"
+	ErrInvalidBid           = "INVALID BID"
+	ErrInvalidCard          = "INVALID CARD"
+	ErrInvalidCutPosition   = "INVALID CUT POSITION"
+	ErrSpectatorsCannotPlay = "SPECTATORS CANNOT PLAY"
+	ErrSpectatorsCannotChat = "SPECTATORS CANNOT CHAT"
+	ErrPlayerNotInGame      = "PLAYER NOT IN GAME"
+)
+
+// PROTOCOL_NAME is the websocket subprotocol a client asks for to exchange envelopes. Clients which do not ask for it
+// keep the legacy text protocol.
+const (
+	PROTOCOL_NAME    = "coinche.v1"
+	PROTOCOL_VERSION = 1
+	INTERNAL_ERROR   = "INTERNAL_ERROR"
+)
+
+const (
+	GameReply         = "game"
+	NotificationReply = "notification"
+	ErrorReply        = "error"
+	AckReply          = "ack"
+	ChatReply         = "chat"
+	ChatHistoryReply  = "chatHistory"
+	MissedEventsReply = "missedEvents"
+	PongReply         = "pong"
+	TournamentReply   = "tournament"
+)
+
+// legacyMessages keeps the texts the clients of the legacy protocol already know.
+var legacyMessages = map[string]string{
+	ErrUnknownMessage:       "Message not understood by the server",
+	ErrInvalidBid:           "Invalid bid",
+	ErrInvalidCard:          "Invalid card",
+	ErrInvalidCutPosition:   "Invalid cut position",
+	ErrSpectatorsCannotPlay: "Spectators cannot play",
+	ErrSpectatorsCannotChat: "Spectators cannot chat",
+	ErrPlayerNotInGame:      "Player not in game",
+}
+
+// Envelope is the message exchanged in both directions with the clients of the protocol. The id of a request is sent
+// back with its ack or its error.
+type Envelope struct {
+	Version int             `json:"version"`
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// RequestPayload only holds the fields used by the type of the request.
+type RequestPayload struct {
+	Team     string          `json:"team,omitempty"`
+	Strategy string          `json:"strategy,omitempty"`
+	Value    domain.BidValue `json:"value,omitempty"`
+	Color    domain.Color    `json:"color,omitempty"`
+	Card     domain.CardID   `json:"card,omitempty"`
+	Cards    []domain.CardID `json:"cards,omitempty"`
+	Position int             `json:"position,omitempty"`
+	Text     string          `json:"text,omitempty"`
+	Reaction string          `json:"reaction,omitempty"`
+}
+
+type ErrorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type NotificationPayload struct {
+	Message string `json:"message"`
+}
+
+type request struct {
+	kind    string
+	id      string
+	payload RequestPayload
+	err     error
+}
+
+// reply is encoded for the protocol of each player, a nil legacy value meaning the legacy protocol has no such message.
+type reply struct {
+	kind    string
+	id      string
+	payload interface{}
+	legacy  interface{}
+}
+
+func (r reply) encode(protocol string) ([]byte, bool) {
+	if protocol != PROTOCOL_NAME {
+		if r.legacy == nil {
+			return nil, false
+		}
+		data, err := json.Marshal(r.legacy)
+		if err != nil {
+			fmt.Println("Error marshal legacy reply: ", err)
+			return nil, false
+		}
+		return data, true
+	}
+
+	envelope := Envelope{Version: PROTOCOL_VERSION, Type: r.kind, ID: r.id}
+	if r.payload != nil {
+		payload, err := json.Marshal(r.payload)
+		if err != nil {
+			fmt.Println("Error marshal reply payload: ", err)
+			return nil, false
+		}
+		envelope.Payload = payload
+	}
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		fmt.Println("Error marshal envelope: ", err)
+		return nil, false
+	}
+	return data, true
+}
+
+// detailedErrors are followed by details which are not part of their code.
+var detailedErrors = []string{domain.ErrNotYourTurn}
+
+func getRootError(err error) error {
+	for errors.Unwrap(err) != nil {
+		err = errors.Unwrap(err)
+	}
+	return err
+}
+
+// getErrorCode derives the code from the constant of the error, so "NOT YOUR TURN" becomes NOT_YOUR_TURN.
+func getErrorCode(err error) string {
+	message := getRootError(err).Error()
+	for _, constant := range detailedErrors {
+		if strings.HasPrefix(message, constant+" ") {
+			message = constant
+		}
+	}
+
+	code := strings.ReplaceAll(message, " ", "_")
+	for _, character := range code {
+		if (character < 'A' || character > 'Z') && (character < '0' || character > '9') && character != '_' {
+			return INTERNAL_ERROR
+		}
+	}
+	return code
+}
+
+func newGameReply(view socketView) reply {
+	return reply{kind: GameReply, payload: view, legacy: view}
+}
+
+func newNotificationReply(message string) reply {
+	return reply{kind: NotificationReply, payload: NotificationPayload{Message: message}, legacy: message}
+}
+
+func newErrorReply(id string, err error) reply {
+	message := err.Error()
+	legacyMessage := message
+	if text, ok := legacyMessages[message]; ok {
+		legacyMessage = text
+	}
+
+	return reply{
+		kind:    ErrorReply,
+		id:      id,
+		payload: ErrorPayload{Code: getErrorCode(err), Message: message},
+		legacy:  legacyMessage,
+	}
+}
+
+func newAckReply(id string) reply {
+	return reply{kind: AckReply, id: id}
+}
+
+func newPongReply(id string) reply {
+	return reply{kind: PongReply, id: id, legacy: "pong"}
+}
+
+func sendReply(connection *websocket.Conn, r reply) error {
+	data, ok := r.encode(connection.Subprotocol())
+	if !ok {
+		return nil
+	}
+	return send(connection, data)
+}
+
+func parseLegacyBid(content string) request {
+	if content == "pass" || content == "coinche" || content == "surcoinche" {
+		return request{kind: content}
+	}
+
+	array := strings.Split(content, ",")
+	if len(array) != 2 {
+		return request{kind: "bid", err: errors.New(ErrInvalidBid)}
+	}
+
+	value, err := strconv.Atoi(array[1])
+	if err != nil {
+		return request{kind: "bid", err: errors.New(ErrInvalidBid)}
+	}
+
+	return request{kind: "bid", payload: RequestPayload{Color: domain.Color(array[0]), Value: domain.BidValue(value)}}
+}
+
+func parseLegacyRequest(message string) request {
+	array := strings.Split(message, ": ")
+	head := array[0]
+	content := strings.Join(array[1:], ": ")
+
+	switch head {
+	case "leave", "start", "ping":
+		return request{kind: head}
+	case "joinTeam":
+		return request{kind: head, payload: RequestPayload{Team: content}}
+	case "cut":
+		position, err := strconv.Atoi(content)
+		if err != nil {
+			return request{kind: head, err: errors.New(ErrInvalidCutPosition)}
+		}
+		return request{kind: head, payload: RequestPayload{Position: position}}
+	case "bid":
+		return parseLegacyBid(content)
+	case "play":
+		return request{kind: head, payload: RequestPayload{Card: domain.CardID(content)}}
+	case "declare":
+		declaredCards := []domain.CardID{}
+		for _, card := range strings.Split(content, ",") {
+			declaredCards = append(declaredCards, domain.CardID(card))
+		}
+		return request{kind: head, payload: RequestPayload{Cards: declaredCards}}
+	case "addBot":
+		array := strings.Split(content, ",")
+		return request{kind: head, payload: RequestPayload{Strategy: array[0], Team: strings.Join(array[1:], ",")}}
+	case "chat":
+		return request{kind: head, payload: RequestPayload{Text: content}}
+	case "react":
+		return request{kind: head, payload: RequestPayload{Reaction: content}}
+	}
+
+	return request{kind: head, err: errors.New(ErrUnknownMessage)}
+}
+
+func parseRequest(message []byte) request {
+	var envelope Envelope
+	err := json.Unmarshal(message, &envelope)
+	if err != nil {
+		return request{err: errors.New(ErrMalformedRequest)}
+	}
+
+	if envelope.Version != PROTOCOL_VERSION {
+		return request{kind: envelope.Type, id: envelope.ID, err: errors.New(ErrUnsupportedVersion)}
+	}
+
+	r := request{kind: envelope.Type, id: envelope.ID}
+	if len(envelope.Payload) > 0 {
+		err = json.Unmarshal(envelope.Payload, &r.payload)
+		if err != nil {
+			r.err = errors.New(ErrMalformedRequest)
+		}
+	}
+
+	return r
+}
+
+// receiveRequest reads the next request in the protocol negotiated by the connection. A request which cannot be
+// understood holds the error to send back, the returned error meaning the connection is lost.
+func receiveRequest(connection *websocket.Conn) (request, error) {
+	if connection.Subprotocol() != PROTOCOL_NAME {
+		message, err := ReceiveMessage(connection)
+		if err != nil {
+			return request{}, err
+		}
+		return parseLegacyRequest(message), nil
+	}
+
+	message, err := receive(connection)
+	if err != nil {
+		return request{}, err
+	}
+	return parseRequest(message), nil
+}
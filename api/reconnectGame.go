@@ -0,0 +1,48 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReconnectGame binds a new socket to the seat given by the session token. The missed events are the ones after the
+// since parameter, or after the disconnection of the player when it is not given.
+func (gameAPIs *GameAPIs) ReconnectGame(context *gin.Context, hub *Hub) {
+	stringID := context.Param("id")
+	gameID, err := strconv.Atoi(stringID)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG ID FORMAT"})
+		return
+	}
+
+	sessionGameID, playerName, err := hub.userUsecases.ResumeSession(context.Query("session"))
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if sessionGameID != gameID {
+		context.JSON(http.StatusForbidden, gin.H{"error": "WRONG GAME"})
+		return
+	}
+
+	since := hub.presence.reconnect(gameID, playerName)
+	stringSince := context.Query("since")
+	if stringSince != "" {
+		since, err = strconv.Atoi(stringSince)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG SINCE FORMAT"})
+			return
+		}
+	}
+
+	connection, err := wsupgrader.Upgrade(context.Writer, context.Request, nil)
+	if err != nil {
+		fmt.Println("Error upgrading socket with reconnecting player: ", err)
+		return
+	}
+
+	ResumeSocketHandler(connection, gameID, playerName, since, hub)
+}
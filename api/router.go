@@ -7,8 +7,15 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(gameUsecases *usecases.GameUsecases, origins []string) (*gin.Engine, *Hub) {
-	gameAPIs := &GameAPIs{Usecases: gameUsecases}
+func SetupRouter(
+	gameUsecases *usecases.GameUsecases,
+	userUsecases *usecases.UserUsecases,
+	tournamentUsecases *usecases.TournamentUsecases,
+	origins []string,
+) (*gin.Engine, *Hub) {
+	gameAPIs := &GameAPIs{Usecases: gameUsecases, Tournaments: tournamentUsecases}
+	userAPIs := &UserAPIs{Usecases: userUsecases}
+	tournamentAPIs := &TournamentAPIs{Usecases: tournamentUsecases}
 
 	router := gin.Default()
 
@@ -19,6 +26,7 @@ func SetupRouter(gameUsecases *usecases.GameUsecases, origins []string) (*gin.En
 		config.AllowAllOrigins = true
 	}
 	config.AllowMethods = []string{"PUT", "PATCH", "GET", "DELETE", "POST"}
+	config.AddAllowHeaders("Authorization")
 
 	router.Use(cors.New(config))
 
@@ -27,18 +35,39 @@ func SetupRouter(gameUsecases *usecases.GameUsecases, origins []string) (*gin.En
 		panic(err)
 	}
 
-	hub := NewHub(gameUsecases)
+	hub := NewHub(gameUsecases, userUsecases)
+	hub.tournamentUsecases = tournamentUsecases
 	go hub.run()
 
+	router.POST("/users/register", userAPIs.Register)
+	router.POST("/users/login", userAPIs.Login)
+
 	router.GET("/games/:id", gameAPIs.GetGame)
-	router.POST("/games/create", gameAPIs.CreateGame)
-	router.DELETE("/games/:id/delete", gameAPIs.deleteGame)
-	router.PATCH("/games/:id/archive", gameAPIs.archiveGame)
-	router.PUT("/games/:id/leave", gameAPIs.leaveGame)
+	router.GET("/games/:id/replay", gameAPIs.GetReplay)
 	router.GET("/games/all", gameAPIs.ListGames)
-	router.GET("/games/:id/join", func(c *gin.Context) {
+
+	router.GET("/tournaments/all", tournamentAPIs.ListTournaments)
+	router.GET("/tournaments/:id", tournamentAPIs.GetTournament)
+	router.GET("/tournaments/:id/bracket", tournamentAPIs.GetBracket)
+	router.GET("/tournaments/:id/standings", tournamentAPIs.GetStandings)
+
+	authenticated := router.Group("/", authenticate(userUsecases))
+	authenticated.POST("/games/create", gameAPIs.CreateGame)
+	authenticated.DELETE("/games/:id/delete", gameAPIs.deleteGame)
+	authenticated.PATCH("/games/:id/archive", gameAPIs.archiveGame)
+	authenticated.PUT("/games/:id/leave", gameAPIs.leaveGame)
+	authenticated.POST("/tournaments/create", tournamentAPIs.CreateTournament)
+	authenticated.POST("/tournaments/:id/pairs", tournamentAPIs.AddPair)
+	authenticated.POST("/tournaments/:id/rounds", tournamentAPIs.StartRound)
+	authenticated.GET("/games/:id/join", func(c *gin.Context) {
 		gameAPIs.JoinGame(c, &hub)
 	})
+	authenticated.GET("/games/:id/watch", func(c *gin.Context) {
+		gameAPIs.WatchGame(c, &hub)
+	})
+	router.GET("/games/:id/reconnect", func(c *gin.Context) {
+		gameAPIs.ReconnectGame(c, &hub)
+	})
 
 	return router, &hub
 }
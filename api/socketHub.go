@@ -1,10 +1,12 @@
 package api
 
 import (
+	"coinche/domain"
 	"coinche/usecases"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -13,17 +15,28 @@ type player struct {
 	hub        *Hub
 	connection *websocket.Conn
 	send       chan []byte
+	name       string
+	session    string
+	spectator  bool
 	mu         sync.Mutex
 }
 
+// socketView gives each seated player the token to resume its seat after a disconnection, and the clock of the player
+// expected to act.
+type socketView struct {
+	domain.PlayerView
+	Session string     `json:",omitempty"`
+	Clock   *TurnClock `json:",omitempty"`
+}
+
 type message struct {
-	data   []byte
+	reply  reply
 	gameID int
 }
 
 type private struct {
 	player *player
-	data   []byte
+	reply  reply
 	gameID int
 }
 
@@ -35,38 +48,62 @@ type subscription struct {
 type Hub struct {
 	games        map[int]map[*player]bool
 	broadcast    chan message
+	views        chan domain.Game
 	single       chan private
+	delayed      chan private
 	register     chan subscription
 	unregister   chan subscription
 	gameUsecases *usecases.GameUsecases
+	userUsecases *usecases.UserUsecases
+	// tournamentUsecases is nil when the games are not run in tournaments.
+	tournamentUsecases *usecases.TournamentUsecases
+	bots               *botTurns
+	presence           *presence
+	timers             *turnTimers
+	spectators         *spectatorDelay
+	chats              *chatLimiter
 }
 
-func NewHub(gameUsecases *usecases.GameUsecases) Hub {
+func NewHub(gameUsecases *usecases.GameUsecases, userUsecases *usecases.UserUsecases) Hub {
 	return Hub{
 		broadcast:    make(chan message),
+		views:        make(chan domain.Game),
 		single:       make(chan private),
+		delayed:      make(chan private),
 		register:     make(chan subscription),
 		unregister:   make(chan subscription),
 		games:        make(map[int]map[*player]bool),
 		gameUsecases: gameUsecases,
+		userUsecases: userUsecases,
+		bots:         &botTurns{playing: make(map[int]bool)},
+		presence:     &presence{lastSequences: make(map[int]map[string]int)},
+		timers:       &turnTimers{clock: systemClock{}, timers: make(map[int]*turnTimer)},
+		spectators:   &spectatorDelay{clock: systemClock{}},
+		chats:        &chatLimiter{clock: systemClock{}, sent: make(map[int]map[string][]time.Time)},
 	}
 }
 
+func (p *player) encode(r reply) ([]byte, bool) {
+	return r.encode(p.connection.Subprotocol())
+}
+
 func sendToPlayerOrUnregister(h *Hub, player *player, data []byte, gameID int) {
 	player.mu.Lock()
 	defer player.mu.Unlock()
 	err := send(player.connection, data)
 	if err != nil {
 		fmt.Println("Error sending message to player: (", err, "). Closing connection")
-		deletePlayerAndGameIfNeeded(h.games, h.games[gameID], player, gameID)
+		deletePlayerAndGameIfNeeded(h, h.games[gameID], player, gameID)
 	}
 }
 
-func deletePlayerAndGameIfNeeded(games map[int]map[*player]bool, players map[*player]bool, player *player, gameID int) {
+// deletePlayerAndGameIfNeeded also forgets the chat rate limits of a game once its last connection is dropped.
+func deletePlayerAndGameIfNeeded(h *Hub, players map[*player]bool, player *player, gameID int) {
 	close(player.send)
 	delete(players, player)
 	if len(players) == 0 {
-		delete(games, gameID)
+		delete(h.games, gameID)
+		h.chats.forget(gameID)
 	}
 }
 
@@ -83,7 +120,7 @@ func unregister(h *Hub, subscription subscription) {
 	players := h.games[subscription.gameID]
 	if players != nil {
 		if _, ok := players[subscription.player]; ok {
-			deletePlayerAndGameIfNeeded(h.games, players, subscription.player, subscription.gameID)
+			deletePlayerAndGameIfNeeded(h, players, subscription.player, subscription.gameID)
 		}
 	}
 }
@@ -91,28 +128,100 @@ func unregister(h *Hub, subscription subscription) {
 func broadcast(h *Hub, message message) {
 	players := h.games[message.gameID]
 	for player := range players {
+		data, ok := player.encode(message.reply)
+		if !ok {
+			continue
+		}
+
+		select {
+		case player.send <- data:
+			sendToPlayerOrUnregister(h, player, data, message.gameID)
+		default:
+			deletePlayerAndGameIfNeeded(h, players, player, message.gameID)
+		}
+	}
+}
+
+func broadcastViews(h *Hub, game domain.Game) {
+	players := h.games[game.ID]
+
+	isWatched := false
+	for player := range players {
+		if !player.spectator {
+			isWatched = true
+		}
+	}
+	clock := h.timers.schedule(h, game, isWatched)
+
+	for player := range players {
+		if player.spectator {
+			sendSpectatorView(h, player, game, clock)
+			continue
+		}
+
+		view := socketView{PlayerView: game.ViewFor(player.name), Session: player.session, Clock: clock}
+		data, ok := player.encode(newGameReply(view))
+		if !ok {
+			continue
+		}
+
 		select {
-		case player.send <- message.data:
-			sendToPlayerOrUnregister(h, player, message.data, message.gameID)
+		case player.send <- data:
+			sendToPlayerOrUnregister(h, player, data, game.ID)
 		default:
-			deletePlayerAndGameIfNeeded(h.games, players, player, message.gameID)
+			deletePlayerAndGameIfNeeded(h, players, player, game.ID)
 		}
 	}
+
+	botName := game.BotToPlay()
+	if botName != "" {
+		go playBot(h, game.ID, botName)
+	}
+
+	if game.IsFinished() && h.tournamentUsecases != nil {
+		go advanceTournament(h, game.ID)
+	}
 }
 
 func single(h *Hub, private private) {
 	players := h.games[private.gameID]
 	player := private.player
 	if _, ok := players[player]; !ok {
-		data, _ := json.Marshal("Player not in game")
+		data, _ := player.encode(newErrorReply(private.reply.id, errors.New(ErrPlayerNotInGame)))
 		sendToPlayerOrUnregister(h, private.player, data, private.gameID)
 	}
 
+	data, ok := player.encode(private.reply)
+	if !ok {
+		return
+	}
+
 	select {
-	case player.send <- private.data:
-		sendToPlayerOrUnregister(h, player, private.data, private.gameID)
+	case player.send <- data:
+		sendToPlayerOrUnregister(h, player, data, private.gameID)
 	default:
-		deletePlayerAndGameIfNeeded(h.games, players, player, private.gameID)
+		deletePlayerAndGameIfNeeded(h, players, player, private.gameID)
+	}
+}
+
+// delayed only sends the data if the player is still in the game when the delay is over.
+func delayed(h *Hub, private private) {
+	players := h.games[private.gameID]
+	player := private.player
+	if _, ok := players[player]; !ok {
+		return
+	}
+
+	data, ok := player.encode(private.reply)
+	if !ok {
+		return
+	}
+
+	select {
+	case player.send <- data:
+		sendToPlayerOrUnregister(h, player, data, private.gameID)
+	default:
+		deletePlayerAndGameIfNeeded(h, players, player, private.gameID)
 	}
 }
 
@@ -129,8 +238,14 @@ func (h *Hub) run() {
 		case message := <-h.broadcast:
 			broadcast(h, message)
 
+		case game := <-h.views:
+			broadcastViews(h, game)
+
 		case private := <-h.single:
 			single(h, private)
+
+		case private := <-h.delayed:
+			delayed(h, private)
 		}
 	}
 }
@@ -25,26 +25,12 @@ func receive(connection *websocket.Conn) ([]byte, error) {
 
 func broadcastGame(game domain.Game, hub *Hub) {
 	fmt.Println("S >>> broadcasting game:", game.ID)
-	data, err := json.Marshal(game)
-	if err != nil {
-		fmt.Println("Error marshal during broadcasting game: " + err.Error())
-		return
-	}
-
-	m := message{data: data, gameID: game.ID}
-
-	hub.broadcast <- m
+	hub.views <- game
 }
 
 func broadcastMessage(msg string, gameID int, hub *Hub) {
 	fmt.Println("S >>> broadcasting message:", msg)
-	data, err := json.Marshal(msg)
-	if err != nil {
-		fmt.Println("Error marshal during broadcasting message: " + err.Error())
-		return
-	}
-
-	m := message{data: data, gameID: gameID}
+	m := message{reply: newNotificationReply(msg), gameID: gameID}
 
 	hub.broadcast <- m
 }
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSocketBots(test *testing.T) {
+	assert := assert.New(test)
+	botDelay = 0
+
+	game := domain.NewGame("GAME ONE")
+
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+
+	hub := NewHub(gameUsecases, newTestUserUsecases())
+	go hub.run()
+
+	s1, c1 := NewGameWebSocketServer(test, 1, "P1", &hub)
+	defer s1.Close()
+	defer c1.Close()
+
+	_ = ReceiveGameOrFatal(c1, test)
+
+	SendMessageOrFatal(c1, "joinTeam: A Team", "P1", test)
+	_ = ReceiveGameOrFatal(c1, test)
+
+	test.Run("Can add bots", func(test *testing.T) {
+		for _, botName := range []string{"Bot 1", "Bot 2", "Bot 3"} {
+			SendMessageOrFatal(c1, "addBot: heuristic", "P1", test)
+
+			reply := ReceiveMessageOrFatal(c1, test)
+			got := ReceiveGameOrFatal(c1, test)
+
+			assert.Equal(botName+" has joined the game", reply)
+			assert.Equal("heuristic", got.Players[botName].Bot)
+		}
+	})
+
+	test.Run("Should fail with an unknown strategy", func(test *testing.T) {
+		SendMessageOrFatal(c1, "addBot: genius", "P1", test)
+
+		reply := ReceiveMessageOrFatal(c1, test)
+
+		assert.Equal("Could not add bot: UNKNOWN STRATEGY", reply)
+	})
+
+	test.Run("Bots play until it is the turn of a human", func(test *testing.T) {
+		SendMessageOrFatal(c1, "start", "P1", test)
+
+		_ = c1.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		var got domain.Game
+		for got.Players["P1"].Order != 1 {
+			_, got = ReceiveMessageOrGameOrFatal(c1, test)
+		}
+
+		assert.Equal(domain.Bidding, got.Phase)
+		assert.Equal(8, len(got.Players["P1"].Hand))
+		assert.NotEqual(0, len(got.Bids))
+	})
+}
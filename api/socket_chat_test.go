@@ -0,0 +1,145 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveChatOrFatal(connection *websocket.Conn, test *testing.T) domain.ChatMessage {
+	message, err := receive(connection)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var envelope chatEnvelope
+	err = json.Unmarshal(message, &envelope)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return envelope.Chat
+}
+
+func TestSocketChat(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+
+	clock := &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hub := NewHub(gameUsecases, newTestUserUsecases())
+	hub.chats.configure(clock)
+	go hub.run()
+
+	s1, c1 := NewGameWebSocketServer(test, 1, "P1", &hub)
+	defer s1.Close()
+	defer c1.Close()
+	_ = ReceiveGameOrFatal(c1, test)
+
+	s2, c2 := NewGameWebSocketServer(test, 1, "P2", &hub)
+	defer s2.Close()
+	defer c2.Close()
+	_ = ReceiveGameOrFatal(c1, test)
+	_ = ReceiveGameOrFatal(c2, test)
+
+	test.Run("Should broadcast a chat message with its sender", func(test *testing.T) {
+		SendMessageOrFatal(c1, "chat: hello: everyone", "P1", test)
+
+		for _, connection := range []*websocket.Conn{c1, c2} {
+			got := receiveChatOrFatal(connection, test)
+
+			assert.Equal("P1", got.Sender)
+			assert.Equal(domain.TextChat, got.Kind)
+			assert.Equal("hello: everyone", got.Content)
+			assert.False(got.CreatedAt.IsZero())
+		}
+	})
+
+	test.Run("Should broadcast a reaction", func(test *testing.T) {
+		SendMessageOrFatal(c2, "react: clap", "P2", test)
+
+		for _, connection := range []*websocket.Conn{c1, c2} {
+			got := receiveChatOrFatal(connection, test)
+
+			assert.Equal("P2", got.Sender)
+			assert.Equal(domain.ReactionChat, got.Kind)
+			assert.Equal("clap", got.Content)
+		}
+	})
+
+	test.Run("Should refuse an unknown reaction", func(test *testing.T) {
+		SendMessageOrFatal(c2, "react: dance", "P2", test)
+
+		assert.Equal("Could not react: UNKNOWN REACTION", ReceiveMessageOrFatal(c2, test))
+	})
+
+	test.Run("Should limit the rate of the messages of a player", func(test *testing.T) {
+		for i := 1; i < CHAT_RATE_LIMIT; i++ {
+			SendMessageOrFatal(c1, fmt.Sprint("chat: message ", i), "P1", test)
+			_ = receiveChatOrFatal(c1, test)
+			_ = receiveChatOrFatal(c2, test)
+		}
+
+		SendMessageOrFatal(c1, "chat: one too many", "P1", test)
+		assert.Equal("Could not chat: TOO MANY MESSAGES", ReceiveMessageOrFatal(c1, test))
+
+		clock.Advance(CHAT_RATE_WINDOW)
+
+		SendMessageOrFatal(c1, "chat: later", "P1", test)
+		assert.Equal("later", receiveChatOrFatal(c1, test).Content)
+		_ = receiveChatOrFatal(c2, test)
+	})
+
+	test.Run("Should send the recent messages to late joiners", func(test *testing.T) {
+		s3, c3 := NewGameWebSocketServer(test, 1, "P3", &hub)
+		defer s3.Close()
+		defer c3.Close()
+
+		_ = ReceiveGameOrFatal(c3, test)
+		message, err := receive(c3)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		var got chatHistory
+		err = json.Unmarshal(message, &got)
+
+		assert.NoError(err)
+		assert.Equal(CHAT_RATE_LIMIT+2, len(got.ChatHistory))
+		assert.Equal("hello: everyone", got.ChatHistory[0].Content)
+		assert.Equal("later", got.ChatHistory[len(got.ChatHistory)-1].Content)
+	})
+}
+
+func TestChatLimits(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+	)
+	hub := NewHub(usecases.NewGameUsecases(&mockRepository), newTestUserUsecases())
+
+	test.Run("Should forget the limits of a game once its last connection is dropped", func(test *testing.T) {
+		p1 := &player{hub: &hub, send: make(chan []byte, 1), name: "P1"}
+		p2 := &player{hub: &hub, send: make(chan []byte, 1), name: "P2"}
+		register(&hub, subscription{player: p1, gameID: 1})
+		register(&hub, subscription{player: p2, gameID: 1})
+		assert.True(hub.chats.allow(1, "P1"))
+
+		unregister(&hub, subscription{player: p1, gameID: 1})
+
+		assert.Contains(hub.chats.sent, 1)
+
+		unregister(&hub, subscription{player: p2, gameID: 1})
+
+		assert.NotContains(hub.chats.sent, 1)
+	})
+}
@@ -20,7 +20,7 @@ func TestFailingSocketHandler(test *testing.T) {
 	)
 	gameUsecases := usecases.NewGameUsecases(&mockRepository)
 
-	hub := NewHub(gameUsecases)
+	hub := NewHub(gameUsecases, newTestUserUsecases())
 	go hub.run()
 	server, connection := NewGameWebSocketServer(test, 3, "P1", &hub)
 
@@ -71,7 +71,7 @@ func TestSocketHandler(test *testing.T) {
 	var s5 *httptest.Server
 	var c5 *websocket.Conn
 
-	hub := NewHub(gameUsecases)
+	hub := NewHub(gameUsecases, newTestUserUsecases())
 	go hub.run()
 
 	test.Run("Can connect and receive the game", func(test *testing.T) {
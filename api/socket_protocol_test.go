@@ -0,0 +1,272 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEnvelopeServer(test *testing.T, ID int, playerName string, hub *Hub) (*httptest.Server, *websocket.Conn) {
+	funcForHandlerFunc := func(w http.ResponseWriter, r *http.Request) {
+		connection, err := wsupgrader.Upgrade(w, r, nil)
+		if err != nil {
+			test.Fatal(err)
+		}
+		PlayerSocketHandler(connection, ID, playerName, hub)
+	}
+	server := httptest.NewServer(http.HandlerFunc(funcForHandlerFunc))
+
+	dialer := websocket.Dialer{Subprotocols: []string{PROTOCOL_NAME}}
+	connection, _, err := dialer.Dial(httpToWS(test, server.URL), nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return server, connection
+}
+
+func sendEnvelopeOrFatal(connection *websocket.Conn, envelope string, test *testing.T) {
+	err := send(connection, []byte(envelope))
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+func receiveEnvelopeOrFatal(connection *websocket.Conn, test *testing.T) Envelope {
+	message, err := receive(connection)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var envelope Envelope
+	err = json.Unmarshal(message, &envelope)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return envelope
+}
+
+func receiveErrorPayloadOrFatal(connection *websocket.Conn, test *testing.T) (Envelope, ErrorPayload) {
+	envelope := receiveEnvelopeOrFatal(connection, test)
+
+	var payload ErrorPayload
+	err := json.Unmarshal(envelope.Payload, &payload)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return envelope, payload
+}
+
+func TestGetErrorCode(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("Should derive the code from the domain constant", func(test *testing.T) {
+		assert.Equal("NOT_YOUR_TURN", getErrorCode(errors.New(domain.ErrNotYourTurn)))
+	})
+
+	test.Run("Should drop the details following the constant", func(test *testing.T) {
+		assert.Equal("NOT_YOUR_TURN", getErrorCode(errors.New(domain.ErrNotYourTurn+" P2 2")))
+	})
+
+	test.Run("Should ignore the context of a wrapped error", func(test *testing.T) {
+		err := fmt.Errorf("Could not bid: %w", errors.New(domain.ErrNotBidding))
+		assert.Equal(strings.ReplaceAll(domain.ErrNotBidding, " ", "_"), getErrorCode(err))
+	})
+
+	test.Run("Should hide an error which is not a constant", func(test *testing.T) {
+		assert.Equal(INTERNAL_ERROR, getErrorCode(errors.New("unexpected EOF")))
+	})
+}
+
+func TestParseLegacyRequest(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("Should parse a bid", func(test *testing.T) {
+		got := parseLegacyRequest("bid: heart,80")
+
+		assert.Equal("bid", got.kind)
+		assert.Equal(RequestPayload{Color: domain.Heart, Value: 80}, got.payload)
+		assert.NoError(got.err)
+	})
+
+	test.Run("Should parse a pass", func(test *testing.T) {
+		assert.Equal("pass", parseLegacyRequest("bid: pass").kind)
+	})
+
+	test.Run("Should parse a cut", func(test *testing.T) {
+		got := parseLegacyRequest("cut: 12")
+
+		assert.Equal("cut", got.kind)
+		assert.Equal(RequestPayload{Position: 12}, got.payload)
+		assert.NoError(got.err)
+	})
+
+	test.Run("Should parse a surcoinche", func(test *testing.T) {
+		assert.Equal("surcoinche", parseLegacyRequest("bid: surcoinche").kind)
+	})
+
+	test.Run("Should refuse an invalid bid", func(test *testing.T) {
+		assert.EqualError(parseLegacyRequest("bid: heart").err, ErrInvalidBid)
+	})
+
+	test.Run("Should keep the separator in a chat message", func(test *testing.T) {
+		assert.Equal("hello: everyone", parseLegacyRequest("chat: hello: everyone").payload.Text)
+	})
+
+	test.Run("Should refuse an unknown message", func(test *testing.T) {
+		assert.EqualError(parseLegacyRequest("hello").err, ErrUnknownMessage)
+	})
+}
+
+func TestSocketProtocol(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+
+	hub := NewHub(gameUsecases, newTestUserUsecases())
+	go hub.run()
+
+	s1, c1 := newEnvelopeServer(test, 1, "P1", &hub)
+	defer s1.Close()
+	defer c1.Close()
+
+	s2, c2 := NewGameWebSocketServer(test, 1, "P2", &hub)
+	defer s2.Close()
+	defer c2.Close()
+
+	test.Run("Should negotiate the protocol", func(test *testing.T) {
+		assert.Equal(PROTOCOL_NAME, c1.Subprotocol())
+		assert.Equal("", c2.Subprotocol())
+	})
+
+	test.Run("Should wrap the game in an envelope", func(test *testing.T) {
+		for i := 0; i < 2; i++ {
+			envelope := receiveEnvelopeOrFatal(c1, test)
+			assert.Equal(PROTOCOL_VERSION, envelope.Version)
+			assert.Equal(GameReply, envelope.Type)
+		}
+
+		game := ReceiveGameOrFatal(c2, test)
+		assert.Equal(2, len(game.Players))
+	})
+
+	test.Run("Should acknowledge a request after broadcasting the game", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `{"version":1,"type":"joinTeam","id":"1","payload":{"team":"A"}}`, test)
+
+		envelope := receiveEnvelopeOrFatal(c1, test)
+		assert.Equal(GameReply, envelope.Type)
+
+		var view socketView
+		err := json.Unmarshal(envelope.Payload, &view)
+		if err != nil {
+			test.Fatal(err)
+		}
+		assert.Equal("A", view.Players["P1"].Team)
+
+		envelope = receiveEnvelopeOrFatal(c1, test)
+		assert.Equal(AckReply, envelope.Type)
+		assert.Equal("1", envelope.ID)
+
+		game := ReceiveGameOrFatal(c2, test)
+		assert.Equal("A", game.Players["P1"].Team)
+	})
+
+	test.Run("Should reply a typed error with the id of the request", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `{"version":1,"type":"bid","id":"2","payload":{"value":80,"color":"heart"}}`, test)
+
+		envelope, payload := receiveErrorPayloadOrFatal(c1, test)
+
+		assert.Equal(ErrorReply, envelope.Type)
+		assert.Equal("2", envelope.ID)
+		assert.Equal(strings.ReplaceAll(domain.ErrNotBidding, " ", "_"), payload.Code)
+		assert.Equal("Could not bid: "+domain.ErrNotBidding, payload.Message)
+	})
+
+	test.Run("Should keep the legacy replies", func(test *testing.T) {
+		SendMessageOrFatal(c2, "play: as-tree", "P2", test)
+		assert.Equal("Invalid card", ReceiveMessageOrFatal(c2, test))
+
+		SendMessageOrFatal(c2, "bid: heart,80", "P2", test)
+		assert.Equal("Could not bid: "+domain.ErrNotBidding, ReceiveMessageOrFatal(c2, test))
+	})
+
+	test.Run("Should refuse an unknown type", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `{"version":1,"type":"dance","id":"3"}`, test)
+
+		envelope, payload := receiveErrorPayloadOrFatal(c1, test)
+
+		assert.Equal("3", envelope.ID)
+		assert.Equal("UNKNOWN_MESSAGE", payload.Code)
+	})
+
+	test.Run("Should refuse an unsupported version", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `{"version":2,"type":"start","id":"4"}`, test)
+
+		envelope, payload := receiveErrorPayloadOrFatal(c1, test)
+
+		assert.Equal("4", envelope.ID)
+		assert.Equal("UNSUPPORTED_VERSION", payload.Code)
+	})
+
+	test.Run("Should refuse a malformed request", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `bid: pass`, test)
+
+		_, payload := receiveErrorPayloadOrFatal(c1, test)
+
+		assert.Equal("MALFORMED_REQUEST", payload.Code)
+	})
+
+	test.Run("Should answer a ping with its id", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `{"version":1,"type":"ping","id":"5"}`, test)
+
+		envelope := receiveEnvelopeOrFatal(c1, test)
+
+		assert.Equal(PongReply, envelope.Type)
+		assert.Equal("5", envelope.ID)
+	})
+
+	test.Run("Should send a chat in both protocols", func(test *testing.T) {
+		sendEnvelopeOrFatal(c1, `{"version":1,"type":"chat","id":"6","payload":{"text":"hello"}}`, test)
+
+		envelope := receiveEnvelopeOrFatal(c1, test)
+		assert.Equal(ChatReply, envelope.Type)
+
+		var chat domain.ChatMessage
+		err := json.Unmarshal(envelope.Payload, &chat)
+		if err != nil {
+			test.Fatal(err)
+		}
+		assert.Equal("hello", chat.Content)
+
+		envelope = receiveEnvelopeOrFatal(c1, test)
+		assert.Equal(AckReply, envelope.Type)
+		assert.Equal("6", envelope.ID)
+
+		assert.Equal("hello", receiveChatOrFatal(c2, test).Content)
+	})
+
+	test.Run("Should send a notification", func(test *testing.T) {
+		SendMessageOrFatal(c2, "leave", "P2", test)
+
+		envelope := receiveEnvelopeOrFatal(c1, test)
+		assert.Equal(NotificationReply, envelope.Type)
+
+		var payload NotificationPayload
+		err := json.Unmarshal(envelope.Payload, &payload)
+		if err != nil {
+			test.Fatal(err)
+		}
+		assert.Equal("P2 has left the game", payload.Message)
+	})
+}
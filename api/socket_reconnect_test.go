@@ -0,0 +1,109 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveSocketViewOrFatal(connection *websocket.Conn, test *testing.T) socketView {
+	message, err := receive(connection)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var view socketView
+	err = json.Unmarshal(message, &view)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return view
+}
+
+func TestSocketReconnect(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c1 := newConnection(test, server.URL+"/games/1/join?token="+newTokenOrFatal(test, userUsecases, "P1"))
+	defer c1.Close()
+	_ = receiveSocketViewOrFatal(c1, test)
+
+	c2 := newConnection(test, server.URL+"/games/1/join?token="+newTokenOrFatal(test, userUsecases, "P2"))
+	view := receiveSocketViewOrFatal(c2, test)
+	_ = receiveSocketViewOrFatal(c1, test)
+	session := view.Session
+
+	test.Run("Should give a session to a seated player", func(test *testing.T) {
+		gameID, name, err := userUsecases.ResumeSession(session)
+
+		assert.NoError(err)
+		assert.Equal(1, gameID)
+		assert.Equal("P2", name)
+	})
+
+	test.Run("Should tell the others that a player has disconnected", func(test *testing.T) {
+		c2.Close()
+
+		reply := ReceiveMessageOrFatal(c1, test)
+
+		assert.Equal("P2 has disconnected", reply)
+	})
+
+	test.Run("Should not reconnect without a valid session", func(test *testing.T) {
+		wsURL := httpToWS(test, server.URL+"/games/1/reconnect?session=forged.session")
+		_, response, err := websocket.DefaultDialer.Dial(wsURL, nil)
+
+		assert.Error(err)
+		assert.Equal(http.StatusUnauthorized, response.StatusCode)
+	})
+
+	test.Run("Should not reconnect to another game", func(test *testing.T) {
+		wsURL := httpToWS(test, server.URL+"/games/2/reconnect?session="+session)
+		_, response, err := websocket.DefaultDialer.Dial(wsURL, nil)
+
+		assert.Error(err)
+		assert.Equal(http.StatusForbidden, response.StatusCode)
+	})
+
+	test.Run("Should resume the seat and send the missed events", func(test *testing.T) {
+		SendMessageOrFatal(c1, "joinTeam: A Team", "P1", test)
+		_ = ReceiveGameOrFatal(c1, test)
+
+		c3 := newConnection(test, server.URL+"/games/1/reconnect?session="+session)
+		defer c3.Close()
+
+		message, err := receive(c3)
+		if err != nil {
+			test.Fatal(err)
+		}
+		var missed missedEvents
+		err = json.Unmarshal(message, &missed)
+		assert.NoError(err)
+		assert.Equal(1, len(missed.MissedEvents))
+		assert.Equal(domain.JoinTeamEvent, missed.MissedEvents[0].Kind)
+		assert.Equal("P1", missed.MissedEvents[0].Player)
+
+		assert.Equal("P2 has reconnected", ReceiveMessageOrFatal(c3, test))
+		got := receiveSocketViewOrFatal(c3, test)
+		assert.Equal("A Team", got.Players["P1"].Team)
+		assert.NotEqual("", got.Session)
+
+		assert.Equal("P2 has reconnected", ReceiveMessageOrFatal(c1, test))
+		_ = ReceiveGameOrFatal(c1, test)
+	})
+}
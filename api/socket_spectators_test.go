@@ -0,0 +1,113 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSocketSpectators(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team"},
+		"P2": {Team: "B Team"},
+		"P3": {Team: "A Team"},
+		"P4": {Team: "B Team"},
+	}
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	err := gameUsecases.StartGame(1)
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = gameUsecases.Cut(1, "P1", 16)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	userUsecases := newTestUserUsecases()
+	router, hub := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c1 := newConnection(test, server.URL+"/games/1/join?token="+newTokenOrFatal(test, userUsecases, "P1"))
+	defer c1.Close()
+	_ = receiveSocketViewOrFatal(c1, test)
+
+	c5 := newConnection(test, server.URL+"/games/1/watch?token="+newTokenOrFatal(test, userUsecases, "S1"))
+
+	test.Run("Should watch a started game without any hand", func(test *testing.T) {
+		got := receiveSocketViewOrFatal(c5, test)
+
+		assert.Equal(domain.Bidding, got.Phase)
+		assert.Equal([]string{"S1"}, got.Spectators)
+		assert.Equal("", got.Session)
+		for _, seat := range got.Players {
+			assert.Nil(seat.Hand)
+			assert.Equal(8, seat.CardsCount)
+		}
+	})
+
+	test.Run("Should show the spectators to the players", func(test *testing.T) {
+		got := receiveSocketViewOrFatal(c1, test)
+
+		assert.Equal([]string{"S1"}, got.Spectators)
+		assert.Equal(8, len(got.Players["P1"].Hand))
+	})
+
+	test.Run("Should not let a spectator play", func(test *testing.T) {
+		SendMessageOrFatal(c5, "bid: pass", "S1", test)
+
+		assert.Equal("Spectators cannot play", ReceiveMessageOrFatal(c5, test))
+	})
+
+	test.Run("Should not watch a game twice", func(test *testing.T) {
+		c6 := newConnection(test, server.URL+"/games/1/watch?token="+newTokenOrFatal(test, userUsecases, "S1"))
+		defer c6.Close()
+
+		assert.Equal("Could not watch this game: ALREADY WATCHING", ReceiveMessageOrFatal(c6, test))
+	})
+
+	test.Run("Should show every hand to coaches after the delay", func(test *testing.T) {
+		clock := &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+		hub.spectators.configure(30*time.Second, clock)
+		defer hub.spectators.configure(0, systemClock{})
+
+		game, _ := gameUsecases.GetGame(1)
+		broadcastGame(game, hub)
+		_ = receiveSocketViewOrFatal(c1, test)
+
+		clock.Advance(30 * time.Second)
+		got := receiveSocketViewOrFatal(c5, test)
+
+		for name, seat := range got.Players {
+			assert.Equal(game.Players[name].Hand, seat.Hand)
+		}
+	})
+
+	test.Run("Should not let a spectator chat while coaches see every hand", func(test *testing.T) {
+		hub.spectators.configure(30*time.Second, systemClock{})
+		defer hub.spectators.configure(0, systemClock{})
+
+		SendMessageOrFatal(c5, "chat: play spades", "S1", test)
+
+		assert.Equal("Spectators cannot chat", ReceiveMessageOrFatal(c5, test))
+	})
+
+	test.Run("Should remove the spectator when leaving", func(test *testing.T) {
+		c5.Close()
+
+		got := receiveSocketViewOrFatal(c1, test)
+
+		assert.Equal([]string{}, got.Spectators)
+	})
+}
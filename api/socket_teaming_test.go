@@ -33,7 +33,7 @@ func CreateConnections(test *testing.T, gameUsecases *usecases.GameUsecases, gam
 	var c4 *websocket.Conn
 	var s4 *httptest.Server
 
-	hub := NewHub(gameUsecases)
+	hub := NewHub(gameUsecases, newTestUserUsecases())
 	go hub.run()
 
 	s1, c1 = NewGameWebSocketServer(test, gameID, "P1", &hub)
@@ -162,7 +162,8 @@ func TestSocketTeaming(test *testing.T) {
 		EmptyMessages([]*websocket.Conn{c2, c3, c4}, 2)
 
 		assert.Equal("GAME ONE", got.Name)
-		assert.Equal(32, len(got.Deck))
+		assert.Equal("BBB", got.Players["P4"].Team)
+		assert.Equal(0, len(got.Deck))
 	})
 
 	test.Run("Can start the game", func(test *testing.T) {
@@ -175,7 +176,36 @@ func TestSocketTeaming(test *testing.T) {
 
 		EmptyMessages([]*websocket.Conn{c2, c1, c4}, 1)
 
+		assert.Equal(domain.Cutting, got.Phase)
+		assert.Equal("P4", got.Dealer)
+	})
+
+	test.Run("Should fail when cutting at an invalid position", func(test *testing.T) {
+		err := SendMessage(c1, "cut: middle", "P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		reply := ReceiveMessageOrFatal(c1, test)
+
+		assert.Equal("Invalid cut position", reply)
+	})
+
+	test.Run("Can cut the deck", func(test *testing.T) {
+		err := SendMessage(c1, "cut: 16", "P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got := ReceiveGameOrFatal(c1, test)
+
+		EmptyMessages([]*websocket.Conn{c2, c3, c4}, 1)
+
 		assert.Equal(domain.Bidding, got.Phase)
+		assert.Equal(8, len(got.Players["P1"].Hand))
+		assert.Equal(0, len(got.Players["P2"].Hand))
+		assert.Equal(0, len(got.Players["P3"].Hand))
+		assert.Equal(0, len(got.Players["P4"].Hand))
 	})
 
 	test.Run("Can place a bid", func(test *testing.T) {
@@ -189,10 +219,7 @@ func TestSocketTeaming(test *testing.T) {
 		EmptyMessages([]*websocket.Conn{c2, c3, c4}, 1)
 
 		assert.Equal("GAME ONE", got.Name)
-		assert.Equal(0, got.Bids[80].Coinche)
-		assert.Equal(0, got.Bids[80].Pass)
-		assert.Equal("P1", got.Bids[80].Player)
-		assert.Equal(domain.Spade, got.Bids[80].Color)
+		assert.Equal([]domain.BidAction{{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: 80, Color: domain.Spade}}, got.Bids)
 	})
 
 	test.Cleanup(func() {
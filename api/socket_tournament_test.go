@@ -0,0 +1,130 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveTournamentOrFatal(connection *websocket.Conn, test *testing.T) TournamentView {
+	message, err := receive(connection)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var envelope tournamentEnvelope
+	err = json.Unmarshal(message, &envelope)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return envelope.Tournament
+}
+
+func TestSocketTournament(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := usecases.NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	tournamentUsecases := newTestTournamentUsecases(gameUsecases)
+
+	tournamentID, err := tournamentUsecases.CreateTournament("CUP", domain.KnockoutFormat, "", 0, 42)
+	if err != nil {
+		test.Fatal(err)
+	}
+	for _, name := range []string{"A", "B", "C"} {
+		err = tournamentUsecases.AddPair(tournamentID, name, []string{name + "1", name + "2"})
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+	tournament, err := tournamentUsecases.StartRound(tournamentID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	gameID := tournament.Tables[0].GameID
+
+	hub := NewHub(gameUsecases, newTestUserUsecases())
+	hub.tournamentUsecases = tournamentUsecases
+	go hub.run()
+
+	s1, c1 := NewGameWebSocketServer(test, gameID, "A1", &hub)
+	defer s1.Close()
+	defer c1.Close()
+	_ = ReceiveGameOrFatal(c1, test)
+
+	test.Run("Should broadcast the bracket once a match is finished", func(test *testing.T) {
+		game, _ := gameUsecases.GetGame(gameID)
+		game.Phase = domain.Finished
+		game.Winner = "A"
+		game.Scores = map[string]int{"A": 1000, "B": 500}
+		err := mockRepository.UpdateGame(game)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		hub.views <- game
+		_ = ReceiveGameOrFatal(c1, test)
+		got := receiveTournamentOrFatal(c1, test)
+
+		assert.Equal(tournamentID, got.Tournament.ID)
+		assert.Equal(2, len(got.Bracket))
+		assert.Equal("A", got.Bracket[0][0].Winner)
+		assert.Equal("A", got.Bracket[1][0].NorthSouth)
+		assert.Equal("C", got.Bracket[1][0].EastWest)
+		assert.Equal(3, len(got.Standings))
+	})
+}
+
+func TestSocketTournamentBoards(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := usecases.NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	tournamentUsecases := newTestTournamentUsecases(gameUsecases)
+
+	tournamentID, err := tournamentUsecases.CreateTournament("CUP", domain.KnockoutFormat, "", 0, 42)
+	if err != nil {
+		test.Fatal(err)
+	}
+	for _, name := range []string{"A", "B", "C", "D"} {
+		err = tournamentUsecases.AddPair(tournamentID, name, []string{name + "1", name + "2"})
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+	tournament, err := tournamentUsecases.StartRound(tournamentID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	otherGameID := tournament.Tables[1].GameID
+	err = gameUsecases.StartGame(otherGameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, tournamentUsecases, []string{})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	test.Run("Should not let a player watch a board of a round in play", func(test *testing.T) {
+		c1 := newConnection(test, fmt.Sprintf("%s/games/%d/watch?token=%s", server.URL, otherGameID, newTokenOrFatal(test, userUsecases, "A1")))
+		defer c1.Close()
+
+		assert.Equal("Could not watch this game: "+domain.ErrRoundNotFinished, ReceiveMessageOrFatal(c1, test))
+	})
+
+	test.Run("Should not let a player without a seat observe a board of a round in play", func(test *testing.T) {
+		c1 := newConnection(test, fmt.Sprintf("%s/games/%d/join?token=%s", server.URL, otherGameID, newTokenOrFatal(test, userUsecases, "A1")))
+		defer c1.Close()
+
+		assert.Equal("Could not join this game: "+domain.ErrRoundNotFinished, ReceiveMessageOrFatal(c1, test))
+	})
+}
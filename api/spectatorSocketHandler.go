@@ -0,0 +1,148 @@
+package api
+
+import (
+	"coinche/domain"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// spectatorDelay is the time after which the spectators receive the views with every hand. Without delay, they
+// receive the views at once but without any hand, so they cannot help the players.
+type spectatorDelay struct {
+	mu    sync.Mutex
+	delay time.Duration
+	clock Clock
+}
+
+func (spectators *spectatorDelay) configure(delay time.Duration, clock Clock) {
+	spectators.mu.Lock()
+	defer spectators.mu.Unlock()
+	spectators.delay = delay
+	spectators.clock = clock
+}
+
+func (spectators *spectatorDelay) get() (time.Duration, Clock) {
+	spectators.mu.Lock()
+	defer spectators.mu.Unlock()
+	return spectators.delay, spectators.clock
+}
+
+// checkChat keeps the spectators who see every hand from coaching the players through the chat.
+func (spectators *spectatorDelay) checkChat() error {
+	delay, _ := spectators.get()
+	if delay > 0 {
+		return errors.New(ErrSpectatorsCannotChat)
+	}
+	return nil
+}
+
+func (h *Hub) SetSpectatorDelay(delay time.Duration) {
+	h.spectators.configure(delay, systemClock{})
+}
+
+func sendSpectatorView(h *Hub, player *player, game domain.Game, clock *TurnClock) {
+	delay, delayClock := h.spectators.get()
+
+	view := socketView{PlayerView: game.SpectatorView(delay > 0)}
+	if delay == 0 {
+		view.Clock = clock
+	}
+
+	if delay > 0 {
+		gameID := game.ID
+		delayClock.AfterFunc(delay, func() {
+			h.delayed <- private{player: player, reply: newGameReply(view), gameID: gameID}
+		})
+		return
+	}
+
+	data, ok := player.encode(newGameReply(view))
+	if !ok {
+		return
+	}
+
+	players := h.games[game.ID]
+	select {
+	case player.send <- data:
+		sendToPlayerOrUnregister(h, player, data, game.ID)
+	default:
+		deletePlayerAndGameIfNeeded(h, players, player, game.ID)
+	}
+}
+
+func stopWatching(hub *Hub, player *player, gameID int) {
+	hub.unregister <- subscription{player: player, gameID: gameID}
+
+	game, err := hub.gameUsecases.StopWatching(gameID, player.name)
+	if err != nil {
+		fmt.Println("Could not stop watching: ", err)
+		return
+	}
+
+	broadcastGame(game, hub)
+}
+
+// SpectatorSocketHandler subscribes the socket to the game without seating the spectator.
+func SpectatorSocketHandler(
+	connection *websocket.Conn,
+	gameID int,
+	spectatorName string,
+	hub *Hub,
+) {
+	game, err := hub.gameUsecases.WatchGame(gameID, spectatorName)
+	if err != nil {
+		closeWithError(connection, "Could not watch this game: ", err)
+		return
+	}
+
+	player := &player{hub: hub, connection: connection, send: make(chan []byte, 256), name: spectatorName, spectator: true}
+	hub.register <- subscription{player: player, gameID: gameID}
+
+	broadcastGame(game, hub)
+	sendChatHistory(player, gameID)
+
+	socketHandler := socketHandler{
+		gameID:       gameID,
+		playerName:   spectatorName,
+		gameUsecases: hub.gameUsecases,
+		player:       player,
+	}
+
+	for {
+		request, err := receiveRequest(connection)
+		if err != nil {
+			fmt.Println("< Error receiving message from spectator: ", err)
+			stopWatching(hub, player, gameID)
+			return
+		}
+
+		if request.err != nil {
+			socketHandler.sendError(request.id, request.err)
+			continue
+		}
+
+		switch request.kind {
+		case "ping":
+			socketHandler.pong(request.id)
+			continue
+		case "chat":
+			err = hub.spectators.checkChat()
+			if err == nil {
+				err = socketHandler.chat(request.payload.Text)
+			}
+		case "react":
+			err = hub.spectators.checkChat()
+			if err == nil {
+				err = socketHandler.react(request.payload.Reaction)
+			}
+		default:
+			err = errors.New(ErrSpectatorsCannotPlay)
+		}
+
+		socketHandler.respond(request.id, err)
+	}
+}
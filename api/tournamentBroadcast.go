@@ -0,0 +1,43 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"fmt"
+)
+
+type TournamentView struct {
+	Tournament domain.TournamentView
+	Bracket    [][]domain.Table
+	Standings  []domain.Standing
+}
+
+type tournamentEnvelope struct {
+	Tournament TournamentView
+}
+
+// advanceTournament runs outside of the hub loop like the bots, as starting the next round creates games. The players
+// of every table of the tournament get the bracket and the standings, so the winners learn their next game.
+func advanceTournament(h *Hub, gameID int) {
+	tournament, err := h.tournamentUsecases.AdvanceTournament(gameID)
+	if err != nil {
+		if err.Error() != usecases.ErrTournamentNotFound {
+			fmt.Println("Could not advance tournament: ", err)
+		}
+		return
+	}
+
+	standings, err := h.tournamentUsecases.GetStandings(tournament.ID)
+	if err != nil {
+		fmt.Println("Could not get tournament standings: ", err)
+		return
+	}
+
+	view := TournamentView{Tournament: tournament.View(), Bracket: tournament.GetBracket(), Standings: standings}
+	r := reply{kind: TournamentReply, payload: view, legacy: tournamentEnvelope{Tournament: view}}
+	for _, table := range tournament.Tables {
+		if table.GameID != 0 {
+			h.broadcast <- message{reply: r, gameID: table.GameID}
+		}
+	}
+}
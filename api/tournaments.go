@@ -0,0 +1,171 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TournamentAPIs struct {
+	Usecases *usecases.TournamentUsecases
+}
+
+type pairBody struct {
+	Name    string
+	Players []string
+}
+
+func getTournamentID(context *gin.Context) (int, bool) {
+	tournamentID, err := strconv.Atoi(context.Param("id"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG ID FORMAT"})
+		return 0, false
+	}
+	return tournamentID, true
+}
+
+// abortWithTournamentError tells a missing tournament from a command refused by the tournament.
+func abortWithTournamentError(context *gin.Context, err error) {
+	if err.Error() == usecases.ErrTournamentNotFound {
+		context.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+func (tournamentAPIs *TournamentAPIs) ListTournaments(context *gin.Context) {
+	tournaments, err := tournamentAPIs.Usecases.ListTournaments()
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	views := []domain.TournamentView{}
+	for _, tournament := range tournaments {
+		views = append(views, tournament.View())
+	}
+
+	context.JSON(http.StatusOK, views)
+}
+
+func (tournamentAPIs *TournamentAPIs) CreateTournament(context *gin.Context) {
+	name := context.Query("name")
+	format := domain.TournamentFormat(context.Query("format"))
+	rules := context.Query("rules")
+
+	var target int
+	stringTarget := context.Query("target")
+	if stringTarget != "" {
+		var err error
+		target, err = strconv.Atoi(stringTarget)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG TARGET FORMAT"})
+			return
+		}
+	}
+
+	var seed int64
+	stringSeed := context.Query("seed")
+	if stringSeed != "" {
+		var err error
+		seed, err = strconv.ParseInt(stringSeed, 10, 64)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG SEED FORMAT"})
+			return
+		}
+	}
+
+	tournamentID, err := tournamentAPIs.Usecases.CreateTournament(name, format, rules, target, seed)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusAccepted, tournamentID)
+}
+
+func (tournamentAPIs *TournamentAPIs) GetTournament(context *gin.Context) {
+	tournamentID, ok := getTournamentID(context)
+	if !ok {
+		return
+	}
+
+	tournament, err := tournamentAPIs.Usecases.GetTournament(tournamentID)
+	if err != nil {
+		abortWithTournamentError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, tournament.View())
+}
+
+func (tournamentAPIs *TournamentAPIs) AddPair(context *gin.Context) {
+	tournamentID, ok := getTournamentID(context)
+	if !ok {
+		return
+	}
+
+	var body pairBody
+	err := context.ShouldBindJSON(&body)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG PAIR FORMAT"})
+		return
+	}
+
+	err = tournamentAPIs.Usecases.AddPair(tournamentID, body.Name, body.Players)
+	if err != nil {
+		abortWithTournamentError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusAccepted, tournamentID)
+}
+
+func (tournamentAPIs *TournamentAPIs) StartRound(context *gin.Context) {
+	tournamentID, ok := getTournamentID(context)
+	if !ok {
+		return
+	}
+
+	tournament, err := tournamentAPIs.Usecases.StartRound(tournamentID)
+	if err != nil {
+		abortWithTournamentError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusAccepted, tournament.View())
+}
+
+func (tournamentAPIs *TournamentAPIs) GetStandings(context *gin.Context) {
+	tournamentID, ok := getTournamentID(context)
+	if !ok {
+		return
+	}
+
+	standings, err := tournamentAPIs.Usecases.GetStandings(tournamentID)
+	if err != nil {
+		abortWithTournamentError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, standings)
+}
+
+// GetBracket gives the tables round by round, with the winner of each finished match.
+func (tournamentAPIs *TournamentAPIs) GetBracket(context *gin.Context) {
+	tournamentID, ok := getTournamentID(context)
+	if !ok {
+		return
+	}
+
+	tournament, err := tournamentAPIs.Usecases.GetTournament(tournamentID)
+	if err != nil {
+		abortWithTournamentError(context, err)
+		return
+	}
+
+	context.JSON(http.StatusOK, tournament.GetBracket())
+}
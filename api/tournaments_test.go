@@ -0,0 +1,232 @@
+package api
+
+import (
+	"bytes"
+	"coinche/domain"
+	"coinche/usecases"
+	testUtilities "coinche/utilities/test"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTournamentUsecases(gameUsecases *usecases.GameUsecases) *usecases.TournamentUsecases {
+	mockRepository := usecases.NewMockTournamentRepo()
+	return usecases.NewTournamentUsecases(&mockRepository, gameUsecases)
+}
+
+func newPairRequest(test *testing.T, tournamentID int, name string, players []string) *http.Request {
+	body, err := json.Marshal(pairBody{Name: name, Players: players})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/tournaments/%d/pairs", tournamentID), bytes.NewReader(body))
+	if err != nil {
+		test.Fatal(err)
+	}
+	return request
+}
+
+func getTournamentOrFatal(test *testing.T, router http.Handler, tournamentID int) domain.TournamentView {
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, testUtilities.GetNewRequest(test, fmt.Sprintf("/tournaments/%d", tournamentID), http.MethodGet))
+
+	var tournament domain.TournamentView
+	err := json.NewDecoder(response.Body).Decode(&tournament)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return tournament
+}
+
+func passOutOrFatal(test *testing.T, gameUsecases *usecases.GameUsecases, gameID int) {
+	for i := 0; i < 4; i++ {
+		game, err := gameUsecases.GetGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		_, err = gameUsecases.Pass(gameID, game.PlayerToAct())
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+}
+
+func TestTournaments(test *testing.T) {
+	assert := assert.New(test)
+	mockRepository := usecases.NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
+	token := newTokenOrFatal(test, userUsecases, "P1")
+
+	test.Run("not create a tournament without token", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.GetNewRequest(test, "/tournaments/create?name=CUP", http.MethodPost))
+
+		assert.Equal(http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("create a tournament", func(test *testing.T) {
+		request := testUtilities.Authorize(testUtilities.GetNewRequest(test, "/tournaments/create?name=CUP&seed=42", http.MethodPost), token)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusAccepted, response.Code)
+		assert.Equal("1", response.Body.String())
+	})
+
+	test.Run("not create a tournament with unknown rules", func(test *testing.T) {
+		request := testUtilities.Authorize(testUtilities.GetNewRequest(test, "/tournaments/create?name=CUP&rules=belote", http.MethodPost), token)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusBadRequest, response.Code)
+	})
+
+	test.Run("seat the pairs", func(test *testing.T) {
+		for _, name := range []string{"A", "B", "C", "D"} {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, testUtilities.Authorize(newPairRequest(test, 1, name, []string{name + "1", name + "2"}), token))
+
+			assert.Equal(http.StatusAccepted, response.Code)
+		}
+	})
+
+	test.Run("not seat an invalid pair", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.Authorize(newPairRequest(test, 1, "E", []string{"E1"}), token))
+
+		assert.Equal(http.StatusBadRequest, response.Code)
+		assert.Contains(response.Body.String(), domain.ErrInvalidPair)
+	})
+
+	test.Run("start a round", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.Authorize(testUtilities.GetNewRequest(test, "/tournaments/1/rounds", http.MethodPost), token))
+
+		var got domain.Tournament
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(http.StatusAccepted, response.Code)
+		assert.Equal(2, len(got.Tables))
+		assert.NotEqual(0, got.Tables[0].GameID)
+	})
+
+	test.Run("not give the seed of the boards", func(test *testing.T) {
+		for _, path := range []string{"/tournaments/1", "/tournaments/all"} {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, testUtilities.GetNewRequest(test, path, http.MethodGet))
+
+			assert.Equal(http.StatusOK, response.Code)
+			assert.NotContains(response.Body.String(), "Seed")
+		}
+	})
+
+	test.Run("hide a board until every table of its round is finished", func(test *testing.T) {
+		tournament := getTournamentOrFatal(test, router, 1)
+		for i, table := range tournament.Tables {
+			passOutOrFatal(test, gameUsecases, table.GameID)
+
+			for _, path := range []string{"/games/%d", "/games/%d/replay"} {
+				response := httptest.NewRecorder()
+				router.ServeHTTP(response, testUtilities.GetNewRequest(test, fmt.Sprintf(path, table.GameID), http.MethodGet))
+
+				if i == 0 {
+					assert.Equal(http.StatusForbidden, response.Code)
+					assert.Contains(response.Body.String(), domain.ErrRoundNotFinished)
+				} else {
+					assert.Equal(http.StatusOK, response.Code)
+				}
+			}
+		}
+	})
+
+	test.Run("view the standings", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.GetNewRequest(test, "/tournaments/1/standings", http.MethodGet))
+
+		var got []domain.Standing
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(http.StatusOK, response.Code)
+		assert.Equal(4, len(got))
+	})
+
+	test.Run("view the bracket", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.GetNewRequest(test, "/tournaments/1/bracket", http.MethodGet))
+
+		var got [][]domain.Table
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(http.StatusOK, response.Code)
+		assert.Equal(1, len(got))
+		assert.Equal(2, len(got[0]))
+	})
+
+	test.Run("create a knockout with its target", func(test *testing.T) {
+		request := testUtilities.Authorize(testUtilities.GetNewRequest(test, "/tournaments/create?name=KO&format=knockout&target=1500", http.MethodPost), token)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusAccepted, response.Code)
+		assert.Equal("2", response.Body.String())
+	})
+
+	test.Run("not create a tournament with an unknown format", func(test *testing.T) {
+		request := testUtilities.Authorize(testUtilities.GetNewRequest(test, "/tournaments/create?name=CUP&format=swiss", http.MethodPost), token)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusBadRequest, response.Code)
+		assert.Contains(response.Body.String(), domain.ErrUnknownFormat)
+	})
+
+	test.Run("not create a tournament with a wrong target", func(test *testing.T) {
+		request := testUtilities.Authorize(testUtilities.GetNewRequest(test, "/tournaments/create?name=CUP&target=many", http.MethodPost), token)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusBadRequest, response.Code)
+		assert.Contains(response.Body.String(), "WRONG TARGET FORMAT")
+	})
+
+	test.Run("list the tournaments", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.GetNewRequest(test, "/tournaments/all", http.MethodGet))
+
+		var got []domain.Tournament
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(http.StatusOK, response.Code)
+		assert.Equal(2, len(got))
+		assert.Equal(domain.KnockoutFormat, got[1].Format)
+		assert.Equal(1500, got[1].Target)
+	})
+
+	test.Run("return 404 on missing tournament", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.GetNewRequest(test, "/tournaments/3", http.MethodGet))
+
+		assert.Equal(http.StatusNotFound, response.Code)
+	})
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"coinche/domain"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Timer interface {
+	Stop() bool
+}
+
+// Clock is injected in the hub so the turn timers can be tested without waiting.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(duration time.Duration, f func()) Timer
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(duration time.Duration, f func()) Timer {
+	return time.AfterFunc(duration, f)
+}
+
+// TurnDurations are the times given to cut, to bid and to play, a zero duration disables the timer.
+type TurnDurations struct {
+	Cut  time.Duration
+	Bid  time.Duration
+	Play time.Duration
+}
+
+// TurnClock is sent with each view so the clients can display the time left to the player expected to act.
+type TurnClock struct {
+	Player                string
+	Deadline              time.Time
+	RemainingMilliseconds int64
+}
+
+type turnTimer struct {
+	key      string
+	player   string
+	deadline time.Time
+	timer    Timer
+}
+
+type turnTimers struct {
+	mu        sync.Mutex
+	clock     Clock
+	durations TurnDurations
+	timers    map[int]*turnTimer
+}
+
+func (timers *turnTimers) configure(durations TurnDurations, clock Clock) {
+	timers.mu.Lock()
+	defer timers.mu.Unlock()
+	timers.durations = durations
+	timers.clock = clock
+}
+
+func (h *Hub) SetTurnDurations(durations TurnDurations) {
+	h.timers.configure(durations, systemClock{})
+}
+
+func (timers *turnTimers) getDuration(phase domain.Phase) time.Duration {
+	switch phase {
+	case domain.Cutting:
+		return timers.durations.Cut
+	case domain.Bidding:
+		return timers.durations.Bid
+	case domain.Playing:
+		return timers.durations.Play
+	}
+	return 0
+}
+
+// getTurnKey changes with every accepted action, so the timer of a player is restarted when they act twice in a row.
+func getTurnKey(game domain.Game, playerName string) string {
+	cardsCount := 0
+	for _, player := range game.Players {
+		cardsCount += len(player.Hand)
+	}
+
+	return fmt.Sprint(game.Phase, "/", playerName, "/", game.Redeals, "/", len(game.Bids), "/", cardsCount)
+}
+
+func (timers *turnTimers) getClock(timer *turnTimer) *TurnClock {
+	return &TurnClock{
+		Player:                timer.player,
+		Deadline:              timer.deadline,
+		RemainingMilliseconds: timer.deadline.Sub(timers.clock.Now()).Milliseconds(),
+	}
+}
+
+func (timers *turnTimers) stop(gameID int) {
+	timer, ok := timers.timers[gameID]
+	if ok {
+		timer.timer.Stop()
+		delete(timers.timers, gameID)
+	}
+}
+
+// schedule starts the timer of the human expected to act, keeping the running one while the action is the same. No
+// timer runs when nobody is connected to the game.
+func (timers *turnTimers) schedule(h *Hub, game domain.Game, isWatched bool) *TurnClock {
+	timers.mu.Lock()
+	defer timers.mu.Unlock()
+
+	playerName := game.PlayerToAct()
+	duration := timers.getDuration(game.Phase)
+
+	if !isWatched || playerName == "" || duration == 0 || game.Players[playerName].IsBot() {
+		timers.stop(game.ID)
+		return nil
+	}
+
+	key := getTurnKey(game, playerName)
+	current, ok := timers.timers[game.ID]
+	if ok && current.key == key {
+		return timers.getClock(current)
+	}
+	timers.stop(game.ID)
+
+	gameID := game.ID
+	timer := &turnTimer{key: key, player: playerName, deadline: timers.clock.Now().Add(duration)}
+	timer.timer = timers.clock.AfterFunc(duration, func() {
+		timeout(h, gameID, key, playerName)
+	})
+	timers.timers[gameID] = timer
+
+	return timers.getClock(timer)
+}
+
+func (timers *turnTimers) expire(gameID int, key string) bool {
+	timers.mu.Lock()
+	defer timers.mu.Unlock()
+
+	current, ok := timers.timers[gameID]
+	if !ok || current.key != key {
+		return false
+	}
+
+	delete(timers.timers, gameID)
+	return true
+}
+
+// timeout runs outside of the hub loop, like the bots, because the automatic move is broadcast through the hub.
+func timeout(h *Hub, gameID int, key string, playerName string) {
+	if !h.timers.expire(gameID, key) {
+		return
+	}
+
+	hasRedealt, err := h.gameUsecases.PlayOnTimeout(gameID, playerName)
+	if err != nil {
+		fmt.Println("Could not play on timeout for ", playerName, ": ", err)
+		return
+	}
+
+	game, err := h.gameUsecases.GetGame(gameID)
+	if err != nil {
+		fmt.Println("Could not get game after timeout: ", err)
+		return
+	}
+
+	broadcastMessage(fmt.Sprint(playerName, " has run out of time"), gameID, h)
+	if hasRedealt {
+		broadcastMessage(redealMessage, gameID, h)
+	}
+	broadcastGame(game, h)
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTimer struct {
+	clock    *fakeClock
+	deadline time.Time
+	f        func()
+	stopped  bool
+}
+
+func (timer *fakeTimer) Stop() bool {
+	timer.clock.mu.Lock()
+	defer timer.clock.mu.Unlock()
+	wasRunning := !timer.stopped
+	timer.stopped = true
+	return wasRunning
+}
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.now
+}
+
+func (clock *fakeClock) AfterFunc(duration time.Duration, f func()) Timer {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	timer := &fakeTimer{clock: clock, deadline: clock.now.Add(duration), f: f}
+	clock.timers = append(clock.timers, timer)
+	return timer
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.mu.Lock()
+	clock.now = clock.now.Add(duration)
+	expired := []*fakeTimer{}
+	for _, timer := range clock.timers {
+		if !timer.stopped && !timer.deadline.After(clock.now) {
+			timer.stopped = true
+			expired = append(expired, timer)
+		}
+	}
+	clock.mu.Unlock()
+
+	for _, timer := range expired {
+		timer.f()
+	}
+}
+
+func TestTurnTimers(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team"},
+		"P2": {Team: "B Team"},
+		"P3": {Team: "A Team"},
+		"P4": {Team: "B Team"},
+	}
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	err := gameUsecases.StartGame(1)
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = gameUsecases.Cut(1, "P1", 16)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	clock := &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hub := NewHub(gameUsecases, newTestUserUsecases())
+	hub.timers.configure(TurnDurations{Bid: 10 * time.Second, Play: 5 * time.Second}, clock)
+	go hub.run()
+
+	s1, c1 := NewGameWebSocketServer(test, 1, "P1", &hub)
+	defer s1.Close()
+	defer c1.Close()
+
+	first := receiveSocketViewOrFatal(c1, test)
+
+	test.Run("Should send the clock of the player expected to act", func(test *testing.T) {
+		started, _ := gameUsecases.GetGame(1)
+
+		assert.Equal(started.PlayerToAct(), first.Clock.Player)
+		assert.Equal(int64(10000), first.Clock.RemainingMilliseconds)
+	})
+
+	test.Run("Should keep the clock running when the action is the same", func(test *testing.T) {
+		clock.Advance(4 * time.Second)
+
+		s2, c2 := NewGameWebSocketServer(test, 1, "P2", &hub)
+		defer s2.Close()
+
+		got := receiveSocketViewOrFatal(c1, test)
+		_ = receiveSocketViewOrFatal(c2, test)
+
+		assert.Equal(first.Clock.Player, got.Clock.Player)
+		assert.Equal(int64(6000), got.Clock.RemainingMilliseconds)
+
+		c2.Close()
+		assert.Equal("P2 has disconnected", ReceiveMessageOrFatal(c1, test))
+	})
+
+	test.Run("Should pass for the player on timeout and start the clock of the next one", func(test *testing.T) {
+		clock.Advance(6 * time.Second)
+
+		reply := ReceiveMessageOrFatal(c1, test)
+		got := receiveSocketViewOrFatal(c1, test)
+
+		assert.Equal(first.Clock.Player+" has run out of time", reply)
+		assert.NotEqual(first.Clock.Player, got.Clock.Player)
+		assert.Equal(int64(10000), got.Clock.RemainingMilliseconds)
+
+		updated, _ := gameUsecases.GetGame(1)
+		assert.Equal(got.Clock.Player, updated.PlayerToAct())
+		assert.Equal(domain.Bidding, updated.Phase)
+	})
+
+	test.Run("Should play the lowest legal card on timeout", func(test *testing.T) {
+		game, _ := gameUsecases.GetGame(1)
+		err := gameUsecases.Bid(1, game.PlayerToAct(), domain.Eighty, domain.Heart)
+		if err != nil {
+			test.Fatal(err)
+		}
+		for game.Phase == domain.Bidding {
+			_, err = gameUsecases.Pass(1, game.PlayerToAct())
+			if err != nil {
+				test.Fatal(err)
+			}
+			game, _ = gameUsecases.GetGame(1)
+		}
+
+		broadcastGame(game, &hub)
+		got := receiveSocketViewOrFatal(c1, test)
+		assert.Equal(domain.Playing, got.Phase)
+		assert.Equal(game.PlayerToAct(), got.Clock.Player)
+		assert.Equal(int64(5000), got.Clock.RemainingMilliseconds)
+
+		playerName := game.PlayerToAct()
+		want, _ := game.LowestLegalCard(playerName)
+
+		clock.Advance(5 * time.Second)
+
+		reply := ReceiveMessageOrFatal(c1, test)
+		got = receiveSocketViewOrFatal(c1, test)
+
+		assert.Equal(playerName+" has run out of time", reply)
+		assert.Equal(want, got.Turns[0].Plays[0].Card)
+	})
+}
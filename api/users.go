@@ -0,0 +1,56 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserAPIs struct {
+	Usecases *usecases.UserUsecases
+}
+
+type credentials struct {
+	Name     string
+	Password string
+}
+
+func (userAPIs *UserAPIs) Register(context *gin.Context) {
+	var body credentials
+	err := context.ShouldBindJSON(&body)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG CREDENTIALS FORMAT"})
+		return
+	}
+
+	token, err := userAPIs.Usecases.Register(body.Name, body.Password)
+	if err != nil && err.Error() == domain.ErrUserAlreadyExists {
+		context.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusCreated, gin.H{"token": token})
+}
+
+func (userAPIs *UserAPIs) Login(context *gin.Context) {
+	var body credentials
+	err := context.ShouldBindJSON(&body)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "WRONG CREDENTIALS FORMAT"})
+		return
+	}
+
+	token, err := userAPIs.Usecases.Login(body.Name, body.Password)
+	if err != nil {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"token": token})
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	testUtilities "coinche/utilities/test"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestUserUsecases() *usecases.UserUsecases {
+	mockRepository := usecases.NewMockUserRepo()
+	userUsecases, err := usecases.NewUserUsecases(&mockRepository, []byte("a secret long enough to sign the tokens"))
+	if err != nil {
+		panic(err)
+	}
+	return userUsecases
+}
+
+func newTokenOrFatal(test *testing.T, userUsecases *usecases.UserUsecases, name string) string {
+	token, err := userUsecases.IssueToken(name)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return token
+}
+
+func decodeTokenOrFatal(test *testing.T, response *httptest.ResponseRecorder) string {
+	var body map[string]string
+	err := json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return body["token"]
+}
+
+func TestUsers(test *testing.T) {
+	assert := assert.New(test)
+	mockRepository := usecases.NewMockGameRepo(
+		map[int]domain.Game{
+			1: {
+				Name:    "GAME ONE",
+				Phase:   domain.Teaming,
+				Players: map[string]domain.Player{"P1": {}, "P2": {}},
+			},
+		},
+	)
+	gameUsecases := usecases.NewGameUsecases(&mockRepository)
+	userUsecases := newTestUserUsecases()
+	router, _ := SetupRouter(gameUsecases, userUsecases, newTestTournamentUsecases(gameUsecases), []string{})
+
+	test.Run("register", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.NewCredentialsRequest(test, "/users/register", "P1", "password"))
+
+		assert.Equal(http.StatusCreated, response.Code)
+
+		name, err := userUsecases.Authenticate(decodeTokenOrFatal(test, response))
+		assert.NoError(err)
+		assert.Equal("P1", name)
+	})
+
+	test.Run("not register a taken name", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.NewCredentialsRequest(test, "/users/register", "P1", "password"))
+
+		assert.Equal(http.StatusConflict, response.Code)
+	})
+
+	test.Run("login", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.NewCredentialsRequest(test, "/users/login", "P1", "password"))
+
+		assert.Equal(http.StatusOK, response.Code)
+
+		name, err := userUsecases.Authenticate(decodeTokenOrFatal(test, response))
+		assert.NoError(err)
+		assert.Equal("P1", name)
+	})
+
+	test.Run("not login with a wrong password", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.NewCredentialsRequest(test, "/users/login", "P1", "wrong password"))
+
+		assert.Equal(http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("not leave a game without token", func(test *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/games/1/leave", nil)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("not leave a game with a forged token", func(test *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/games/1/leave", nil)
+		testUtilities.Authorize(request, "forged.token")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("not join a game without token", func(test *testing.T) {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, testUtilities.NewJoinGameRequest(test, 1, ""))
+
+		assert.Equal(http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("leave a game as the player of the token", func(test *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/games/1/leave?playerName=P1", nil)
+		testUtilities.Authorize(request, newTokenOrFatal(test, userUsecases, "P2"))
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(http.StatusAccepted, response.Code)
+
+		game, err := gameUsecases.GetGame(1)
+		assert.NoError(err)
+		assert.Contains(game.Players, "P1")
+		assert.NotContains(game.Players, "P2")
+	})
+}
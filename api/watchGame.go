@@ -0,0 +1,28 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gameAPIs *GameAPIs) WatchGame(context *gin.Context, hub *Hub) {
+	stringID := context.Param("id")
+	gameID, err := strconv.Atoi(stringID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	spectatorName := context.GetString(playerNameKey)
+
+	connection, err := wsupgrader.Upgrade(context.Writer, context.Request, nil)
+	if err != nil {
+		fmt.Println("Error upgrading socket with new spectator: ", err)
+		return
+	}
+
+	SpectatorSocketHandler(connection, gameID, spectatorName, hub)
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassword(test *testing.T) {
+	assert := assert.New(test)
+
+	hash, err := HashPassword("a good password")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("should not store the password in clear", func(test *testing.T) {
+		assert.NotContains(hash, "a good password")
+	})
+
+	test.Run("should accept the right password", func(test *testing.T) {
+		assert.True(CheckPassword(hash, "a good password"))
+	})
+
+	test.Run("should refuse a wrong password", func(test *testing.T) {
+		assert.False(CheckPassword(hash, "a bad password"))
+	})
+}
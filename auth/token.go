@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	ErrInvalidToken = "INVALID TOKEN"
+	ErrExpiredToken = "EXPIRED TOKEN"
+	ErrWeakSecret   = "WEAK TOKEN SECRET"
+)
+
+const MIN_SECRET_LENGTH = 32
+
+// CheckSecret refuses a short secret, as anyone could sign the tokens of any user with an empty one.
+func CheckSecret(secret []byte) error {
+	if len(secret) < MIN_SECRET_LENGTH {
+		return errors.New(ErrWeakSecret)
+	}
+	return nil
+}
+
+type claims struct {
+	Name      string `json:"name"`
+	Game      int    `json:"game,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func sign(payload string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newToken(tokenClaims claims, secret []byte) (string, error) {
+	data, err := json.Marshal(tokenClaims)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + sign(payload, secret), nil
+}
+
+func parseToken(token string, secret []byte) (claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return claims{}, errors.New(ErrInvalidToken)
+	}
+
+	if !hmac.Equal([]byte(sign(parts[0], secret)), []byte(parts[1])) {
+		return claims{}, errors.New(ErrInvalidToken)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return claims{}, errors.New(ErrInvalidToken)
+	}
+
+	var tokenClaims claims
+	err = json.Unmarshal(data, &tokenClaims)
+	if err != nil || tokenClaims.Name == "" {
+		return claims{}, errors.New(ErrInvalidToken)
+	}
+
+	if time.Now().Unix() > tokenClaims.ExpiresAt {
+		return claims{}, errors.New(ErrExpiredToken)
+	}
+
+	return tokenClaims, nil
+}
+
+// NewToken returns a token made of the encoded claims and of their HMAC-SHA256 signature, separated by a dot.
+func NewToken(name string, secret []byte, duration time.Duration) (string, error) {
+	return newToken(claims{Name: name, ExpiresAt: time.Now().Add(duration).Unix()}, secret)
+}
+
+// ParseToken checks the signature and the expiration of the token and returns the name it has been issued for.
+func ParseToken(token string, secret []byte) (string, error) {
+	tokenClaims, err := parseToken(token, secret)
+	if err != nil {
+		return "", err
+	}
+
+	if tokenClaims.Game != 0 {
+		return "", errors.New(ErrInvalidToken)
+	}
+
+	return tokenClaims.Name, nil
+}
+
+// NewSessionToken returns a token bound to the seat of a player in a game, used to resume the game after a disconnection.
+func NewSessionToken(gameID int, name string, secret []byte, duration time.Duration) (string, error) {
+	return newToken(claims{Name: name, Game: gameID, ExpiresAt: time.Now().Add(duration).Unix()}, secret)
+}
+
+func ParseSessionToken(token string, secret []byte) (int, string, error) {
+	tokenClaims, err := parseToken(token, secret)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if tokenClaims.Game == 0 {
+		return 0, "", errors.New(ErrInvalidToken)
+	}
+
+	return tokenClaims.Game, tokenClaims.Name, nil
+}
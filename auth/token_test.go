@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(test *testing.T) {
+	assert := assert.New(test)
+	secret := []byte("secret")
+
+	test.Run("should give back the name of a valid token", func(test *testing.T) {
+		token, err := NewToken("P1", secret, time.Hour)
+		assert.NoError(err)
+
+		got, err := ParseToken(token, secret)
+
+		assert.NoError(err)
+		assert.Equal("P1", got)
+	})
+
+	test.Run("should refuse a token signed with another secret", func(test *testing.T) {
+		token, err := NewToken("P1", []byte("other"), time.Hour)
+		assert.NoError(err)
+
+		_, err = ParseToken(token, secret)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidToken, err.Error())
+	})
+
+	test.Run("should refuse a token whose name has been changed", func(test *testing.T) {
+		token, err := NewToken("P1", secret, time.Hour)
+		assert.NoError(err)
+		other, err := NewToken("P2", secret, time.Hour)
+		assert.NoError(err)
+
+		forged := strings.Split(other, ".")[0] + "." + strings.Split(token, ".")[1]
+		_, err = ParseToken(forged, secret)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidToken, err.Error())
+	})
+
+	test.Run("should refuse an expired token", func(test *testing.T) {
+		token, err := NewToken("P1", secret, -time.Minute)
+		assert.NoError(err)
+
+		_, err = ParseToken(token, secret)
+
+		assert.Error(err)
+		assert.Equal(ErrExpiredToken, err.Error())
+	})
+
+	test.Run("should refuse a malformed token", func(test *testing.T) {
+		_, err := ParseToken("not a token", secret)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidToken, err.Error())
+	})
+
+	test.Run("should not accept a session token as a login token", func(test *testing.T) {
+		token, err := NewSessionToken(1, "P1", secret, time.Hour)
+		assert.NoError(err)
+
+		_, err = ParseToken(token, secret)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidToken, err.Error())
+	})
+}
+
+func TestSessionToken(test *testing.T) {
+	assert := assert.New(test)
+	secret := []byte("secret")
+
+	test.Run("should give back the game and the name of a valid session", func(test *testing.T) {
+		token, err := NewSessionToken(4, "P1", secret, time.Hour)
+		assert.NoError(err)
+
+		gameID, name, err := ParseSessionToken(token, secret)
+
+		assert.NoError(err)
+		assert.Equal(4, gameID)
+		assert.Equal("P1", name)
+	})
+
+	test.Run("should not accept a login token as a session token", func(test *testing.T) {
+		token, err := NewToken("P1", secret, time.Hour)
+		assert.NoError(err)
+
+		_, _, err = ParseSessionToken(token, secret)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidToken, err.Error())
+	})
+}
+
+func TestSecret(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should refuse an empty secret", func(test *testing.T) {
+		err := CheckSecret([]byte{})
+
+		assert.Error(err)
+		assert.Equal(ErrWeakSecret, err.Error())
+	})
+
+	test.Run("should refuse a short secret", func(test *testing.T) {
+		err := CheckSecret([]byte(strings.Repeat("s", MIN_SECRET_LENGTH-1)))
+
+		assert.Error(err)
+	})
+
+	test.Run("should accept a long secret", func(test *testing.T) {
+		assert.NoError(CheckSecret([]byte(strings.Repeat("s", MIN_SECRET_LENGTH))))
+	})
+}
@@ -0,0 +1,174 @@
+package bot
+
+import (
+	"coinche/domain"
+)
+
+// MAX_REDEALS is the number of redeals after which the heuristic bot opens the bidding whatever its hand.
+const MAX_REDEALS = 3
+
+// Heuristic bids on its best suit and plays like a careful beginner: it leads its aces, loads the tricks won by its
+// partner and wins the other ones as cheaply as possible.
+type Heuristic struct{}
+
+func isTrump(card domain.CardID, trump domain.Color) bool {
+	return trump == domain.AllTrump || card.Color() == trump
+}
+
+func hasBelote(hand []domain.CardID, trump domain.Color) bool {
+	hasQueen := false
+	hasKing := false
+	for _, card := range hand {
+		if card.Color() != trump {
+			continue
+		}
+		if card.Strength(trump) == domain.TQueen {
+			hasQueen = true
+		}
+		if card.Strength(trump) == domain.TKing {
+			hasKing = true
+		}
+	}
+	return hasQueen && hasKing
+}
+
+// evaluateHand estimates the points the hand can make with the given trump.
+func evaluateHand(hand []domain.CardID, trump domain.Color) int {
+	estimate := 0
+	for _, card := range hand {
+		if card.Color() == trump {
+			estimate += card.Points(trump) + 10
+		} else if card.Strength(trump) == domain.As {
+			estimate += 10
+		}
+	}
+
+	if hasBelote(hand, trump) {
+		estimate += 20
+	}
+
+	return estimate
+}
+
+func getBestSuit(hand []domain.CardID) (domain.Color, int) {
+	bestSuit := suits[0]
+	bestEstimate := -1
+	for _, suit := range suits {
+		estimate := evaluateHand(hand, suit)
+		if estimate > bestEstimate {
+			bestSuit = suit
+			bestEstimate = estimate
+		}
+	}
+	return bestSuit, bestEstimate
+}
+
+func (heuristic Heuristic) shouldCoinche(hand []domain.CardID, contract domain.Bid, value domain.BidValue) bool {
+	if contract.Color == domain.NoTrump || contract.Color == domain.AllTrump {
+		return false
+	}
+
+	return evaluateHand(hand, contract.Color) >= int(value)
+}
+
+func (heuristic Heuristic) ChooseBid(view domain.PlayerView, playerName string) BidChoice {
+	hand := view.Players[playerName].Hand
+	lastBid, maxValue := domain.GetLastBid(view.Bids)
+
+	if lastBid.Coinche > 0 {
+		return BidChoice{Action: Pass}
+	}
+
+	isPartnerContract := maxValue > 0 && isPartner(view, playerName, lastBid.Player)
+
+	if maxValue > 0 && !isPartnerContract && heuristic.shouldCoinche(hand, lastBid, maxValue) {
+		return BidChoice{Action: Coinche}
+	}
+
+	value := getNextBidValue(view, maxValue)
+	if value == 0 || isPartnerContract {
+		return BidChoice{Action: Pass}
+	}
+
+	suit, estimate := getBestSuit(hand)
+
+	if int(value) <= estimate || (maxValue == 0 && view.Redeals >= MAX_REDEALS) {
+		return BidChoice{Action: Bid, Value: value, Color: suit}
+	}
+
+	return BidChoice{Action: Pass}
+}
+
+func getLowestCard(cards []domain.CardID, trump domain.Color) domain.CardID {
+	lowest := cards[0]
+	for _, card := range cards[1:] {
+		if card.Points(trump) < lowest.Points(trump) ||
+			(card.Points(trump) == lowest.Points(trump) && card.Strength(trump) < lowest.Strength(trump)) {
+			lowest = card
+		}
+	}
+	return lowest
+}
+
+func getHighestCard(cards []domain.CardID, trump domain.Color) domain.CardID {
+	highest := cards[0]
+	for _, card := range cards[1:] {
+		if card.Points(trump) > highest.Points(trump) ||
+			(card.Points(trump) == highest.Points(trump) && card.Strength(trump) > highest.Strength(trump)) {
+			highest = card
+		}
+	}
+	return highest
+}
+
+func getWinningCards(trick domain.Turn, playerName string, legalCards []domain.CardID, trump domain.Color) []domain.CardID {
+	winningCards := []domain.CardID{}
+	for _, card := range legalCards {
+		plays := append([]domain.Play{}, trick.Plays...)
+		plays = append(plays, domain.Play{PlayerName: playerName, Card: card})
+
+		if (domain.Turn{Plays: plays}).GetWinner(trump) == playerName {
+			winningCards = append(winningCards, card)
+		}
+	}
+	return winningCards
+}
+
+func lead(legalCards []domain.CardID, trump domain.Color) domain.CardID {
+	for _, card := range legalCards {
+		if !isTrump(card, trump) && card.Strength(trump) == domain.As {
+			return card
+		}
+	}
+	return getLowestCard(legalCards, trump)
+}
+
+func (heuristic Heuristic) ChooseCard(view domain.PlayerView, playerName string, legalCards []domain.CardID) domain.CardID {
+	trump := view.Trump
+	trick := getCurrentTrick(view.Turns)
+
+	if len(trick.Plays) == 0 {
+		return lead(legalCards, trump)
+	}
+
+	if isPartner(view, playerName, trick.GetWinner(trump)) {
+		nonTrumps := []domain.CardID{}
+		for _, card := range legalCards {
+			if !isTrump(card, trump) {
+				nonTrumps = append(nonTrumps, card)
+			}
+		}
+
+		if len(nonTrumps) > 0 {
+			return getHighestCard(nonTrumps, trump)
+		}
+		return getLowestCard(legalCards, trump)
+	}
+
+	winningCards := getWinningCards(trick, playerName, legalCards, trump)
+	if len(winningCards) > 0 {
+		return getLowestCard(winningCards, trump)
+	}
+
+	return getLowestCard(legalCards, trump)
+}
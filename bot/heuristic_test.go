@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"coinche/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBiddingView(hand []domain.CardID) domain.PlayerView {
+	return domain.PlayerView{
+		Phase: domain.Bidding,
+		Players: map[string]domain.SeatView{
+			"P1": {Team: "odd", Order: 1, Hand: hand},
+			"P2": {Team: "even", Order: 2},
+			"P3": {Team: "odd", Order: 3},
+			"P4": {Team: "even", Order: 4},
+		},
+		Bids:  []domain.BidAction{},
+		Rules: domain.ClassicRules,
+	}
+}
+
+func newPlayingView(trump domain.Color, plays []domain.Play) domain.PlayerView {
+	return domain.PlayerView{
+		Phase: domain.Playing,
+		Players: map[string]domain.SeatView{
+			"P1": {Team: "odd"},
+			"P2": {Team: "even"},
+			"P3": {Team: "odd"},
+			"P4": {Team: "even"},
+		},
+		Turns: []domain.Turn{{Plays: plays}},
+		Trump: trump,
+		Rules: domain.ClassicRules,
+	}
+}
+
+func TestHeuristicBid(test *testing.T) {
+	assert := assert.New(test)
+	heuristic := Heuristic{}
+
+	test.Run("should bid on a strong suit", func(test *testing.T) {
+		view := newBiddingView([]domain.CardID{domain.HJ, domain.H9, domain.HA, domain.H7, domain.SA, domain.C7, domain.C8, domain.D7})
+
+		got := heuristic.ChooseBid(view, "P1")
+
+		assert.Equal(BidChoice{Action: Bid, Value: domain.Eighty, Color: domain.Heart}, got)
+	})
+
+	test.Run("should pass with a weak hand", func(test *testing.T) {
+		view := newBiddingView([]domain.CardID{domain.H7, domain.H8, domain.S7, domain.S8, domain.C7, domain.C8, domain.D7, domain.D8})
+
+		got := heuristic.ChooseBid(view, "P1")
+
+		assert.Equal(Pass, got.Action)
+	})
+
+	test.Run("should not bid over its partner", func(test *testing.T) {
+		view := newBiddingView([]domain.CardID{domain.HJ, domain.H9, domain.HA, domain.H7, domain.SA, domain.C7, domain.C8, domain.D7})
+		view.Bids = []domain.BidAction{{Kind: domain.PlaceBidAction, Player: "P3", Value: domain.Eighty, Color: domain.Spade}}
+
+		got := heuristic.ChooseBid(view, "P1")
+
+		assert.Equal(Pass, got.Action)
+	})
+
+	test.Run("should coinche an opponent holding its trumps", func(test *testing.T) {
+		view := newBiddingView([]domain.CardID{domain.HJ, domain.H9, domain.HA, domain.H7, domain.SA, domain.C7, domain.C8, domain.D7})
+		view.Bids = []domain.BidAction{{Kind: domain.PlaceBidAction, Player: "P2", Value: domain.Eighty, Color: domain.Heart}}
+
+		got := heuristic.ChooseBid(view, "P1")
+
+		assert.Equal(Coinche, got.Action)
+	})
+
+	test.Run("should open the bidding after too many redeals", func(test *testing.T) {
+		view := newBiddingView([]domain.CardID{domain.H7, domain.H8, domain.H9, domain.S8, domain.C7, domain.C8, domain.D7, domain.D8})
+		view.Redeals = MAX_REDEALS
+
+		got := heuristic.ChooseBid(view, "P1")
+
+		assert.Equal(BidChoice{Action: Bid, Value: domain.Eighty, Color: domain.Heart}, got)
+	})
+}
+
+func TestHeuristicCard(test *testing.T) {
+	assert := assert.New(test)
+	heuristic := Heuristic{}
+
+	test.Run("should lead with an ace", func(test *testing.T) {
+		view := newPlayingView(domain.Heart, []domain.Play{})
+
+		got := heuristic.ChooseCard(view, "P1", []domain.CardID{domain.C7, domain.SA, domain.HA})
+
+		assert.Equal(domain.SA, got)
+	})
+
+	test.Run("should load the trick won by its partner", func(test *testing.T) {
+		view := newPlayingView(domain.Heart, []domain.Play{
+			{PlayerName: "P3", Card: domain.CA},
+			{PlayerName: "P4", Card: domain.C7},
+		})
+
+		got := heuristic.ChooseCard(view, "P1", []domain.CardID{domain.C8, domain.C10, domain.CQ})
+
+		assert.Equal(domain.C10, got)
+	})
+
+	test.Run("should win the trick as cheaply as possible", func(test *testing.T) {
+		view := newPlayingView(domain.Heart, []domain.Play{
+			{PlayerName: "P2", Card: domain.CQ},
+		})
+
+		got := heuristic.ChooseCard(view, "P1", []domain.CardID{domain.C7, domain.CK, domain.CA})
+
+		assert.Equal(domain.CK, got)
+	})
+
+	test.Run("should give its smallest card when it cannot win", func(test *testing.T) {
+		view := newPlayingView(domain.Heart, []domain.Play{
+			{PlayerName: "P2", Card: domain.CA},
+		})
+
+		got := heuristic.ChooseCard(view, "P1", []domain.CardID{domain.C10, domain.C7, domain.CK})
+
+		assert.Equal(domain.C7, got)
+	})
+}
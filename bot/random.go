@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"coinche/domain"
+	"math/rand"
+)
+
+// Random bids once in a while and plays any legal card.
+type Random struct {
+	rng *rand.Rand
+}
+
+func NewRandom(seed int64) Random {
+	return Random{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (random Random) ChooseBid(view domain.PlayerView, playerName string) BidChoice {
+	lastBid, maxValue := domain.GetLastBid(view.Bids)
+	if lastBid.Coinche > 0 {
+		return BidChoice{Action: Pass}
+	}
+
+	value := getNextBidValue(view, maxValue)
+	if value == 0 || random.rng.Intn(4) != 0 {
+		return BidChoice{Action: Pass}
+	}
+
+	return BidChoice{
+		Action: Bid,
+		Value:  value,
+		Color:  suits[random.rng.Intn(len(suits))],
+	}
+}
+
+func (random Random) ChooseCard(view domain.PlayerView, playerName string, legalCards []domain.CardID) domain.CardID {
+	return legalCards[random.rng.Intn(len(legalCards))]
+}
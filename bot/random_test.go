@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"coinche/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should only play legal cards", func(test *testing.T) {
+		random := NewRandom(42)
+		view := newPlayingView(domain.Heart, []domain.Play{})
+		legalCards := []domain.CardID{domain.C7, domain.SA}
+
+		for i := 0; i < 20; i++ {
+			assert.Contains(legalCards, random.ChooseCard(view, "P1", legalCards))
+		}
+	})
+
+	test.Run("should only place bids above the last one", func(test *testing.T) {
+		random := NewRandom(42)
+		view := newBiddingView([]domain.CardID{})
+		view.Bids = []domain.BidAction{{Kind: domain.PlaceBidAction, Player: "P2", Value: domain.HundredAndTwenty, Color: domain.Spade}}
+
+		for i := 0; i < 20; i++ {
+			got := random.ChooseBid(view, "P1")
+			if got.Action == Bid {
+				assert.Equal(domain.HundredAndThirty, got.Value)
+			} else {
+				assert.Equal(Pass, got.Action)
+			}
+		}
+	})
+
+	test.Run("should pass when the bidding is closed", func(test *testing.T) {
+		random := NewRandom(42)
+		view := newBiddingView([]domain.CardID{})
+		view.Bids = []domain.BidAction{{Kind: domain.PlaceBidAction, Player: "P2", Value: domain.Capot, Color: domain.Spade}}
+
+		for i := 0; i < 20; i++ {
+			assert.Equal(Pass, random.ChooseBid(view, "P1").Action)
+		}
+	})
+}
+
+func TestNewStrategy(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should create known strategies", func(test *testing.T) {
+		_, err := NewStrategy(RandomStrategyName)
+		assert.NoError(err)
+
+		_, err = NewStrategy(HeuristicStrategyName)
+		assert.NoError(err)
+	})
+
+	test.Run("should fail with an unknown strategy", func(test *testing.T) {
+		_, err := NewStrategy("genius")
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownStrategy, err.Error())
+	})
+}
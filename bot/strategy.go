@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"coinche/domain"
+	"errors"
+	"time"
+)
+
+const (
+	ErrUnknownStrategy = "UNKNOWN STRATEGY"
+	ErrNotABot         = "NOT A BOT"
+	ErrNothingToPlay   = "NOTHING TO PLAY"
+)
+
+const (
+	RandomStrategyName    = "random"
+	HeuristicStrategyName = "heuristic"
+)
+
+type BidAction string
+
+const (
+	Pass    BidAction = "pass"
+	Bid     BidAction = "bid"
+	Coinche BidAction = "coinche"
+)
+
+type BidChoice struct {
+	Action BidAction
+	Value  domain.BidValue
+	Color  domain.Color
+}
+
+type Strategy interface {
+	ChooseBid(view domain.PlayerView, playerName string) BidChoice
+	ChooseCard(view domain.PlayerView, playerName string, legalCards []domain.CardID) domain.CardID
+}
+
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case RandomStrategyName:
+		return NewRandom(time.Now().UnixNano()), nil
+	case HeuristicStrategyName:
+		return Heuristic{}, nil
+	}
+
+	return nil, errors.New(ErrUnknownStrategy)
+}
+
+var suits = []domain.Color{domain.Club, domain.Diamond, domain.Heart, domain.Spade}
+
+// getNextBidValue returns the smallest value allowed after the highest bid, or 0 if no bid can be placed anymore.
+func getNextBidValue(view domain.PlayerView, maxValue domain.BidValue) domain.BidValue {
+	minBid := view.Rules.MinBid
+	if view.Rules.Name == "" {
+		minBid = domain.ClassicRules.MinBid
+	}
+
+	value := maxValue + 10
+	if value < minBid {
+		value = minBid
+	}
+
+	if value > domain.Capot {
+		return 0
+	}
+
+	return value
+}
+
+func isPartner(view domain.PlayerView, playerName string, otherName string) bool {
+	return view.Players[playerName].Team == view.Players[otherName].Team
+}
+
+func getCurrentTrick(turns []domain.Turn) domain.Turn {
+	if len(turns) == 0 {
+		return domain.Turn{}
+	}
+
+	lastTurn := turns[len(turns)-1]
+	if len(lastTurn.Plays) >= 4 {
+		return domain.Turn{}
+	}
+
+	return lastTurn
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 )
 
 const (
@@ -12,8 +15,19 @@ const (
 	ErrNotTeaming      = "NOT IN TEAMING PHASE"
 	ErrTeamFull        = "TEAM IS FULL"
 	ErrTeamsNotEqual   = "TEAMS ARE NOT EQUAL"
+	ErrBotSeat         = "SEAT TAKEN BY A BOT"
 )
 
+const (
+	BOT_NAME_PREFIX = "Bot"
+	BOT_TEAM_PREFIX = "Bots"
+)
+
+// IsBotName tells whether the name is one given to the bots, which no player can take.
+func IsBotName(name string) bool {
+	return strings.HasPrefix(name, BOT_NAME_PREFIX+" ")
+}
+
 func (game Game) IsFull() bool {
 	return len(game.Players) == 4
 }
@@ -43,6 +57,71 @@ func (game *Game) AddPlayer(playerName string) error {
 	return nil
 }
 
+func (game Game) newBotName() string {
+	for i := 1; ; i++ {
+		name := fmt.Sprint(BOT_NAME_PREFIX, " ", i)
+		if _, ok := game.Players[name]; !ok {
+			return name
+		}
+	}
+}
+
+// getTeamToComplete returns the first team, in alphabetical order, that only has one player.
+// Without such a team, it returns the name of a new team.
+func (game Game) getTeamToComplete() string {
+	teamSizes := map[string]int{}
+	for _, player := range game.Players {
+		if player.Team != "" {
+			teamSizes[player.Team]++
+		}
+	}
+
+	teams := []string{}
+	for team, size := range teamSizes {
+		if size == 1 {
+			teams = append(teams, team)
+		}
+	}
+
+	if len(teams) == 0 {
+		for i := 1; ; i++ {
+			team := fmt.Sprint(BOT_TEAM_PREFIX, " ", i)
+			if teamSizes[team] == 0 {
+				return team
+			}
+		}
+	}
+
+	sort.Strings(teams)
+	return teams[0]
+}
+
+// AddBot seats a bot playing with the given strategy. Without a team name, the bot completes a team.
+func (game *Game) AddBot(strategy string, teamName string) (string, error) {
+	botName := game.newBotName()
+
+	err := game.AddPlayer(botName)
+	if err != nil {
+		return "", err
+	}
+
+	bot := game.Players[botName]
+	bot.Bot = strategy
+	game.Players[botName] = bot
+
+	if teamName == "" {
+		teamName = game.getTeamToComplete()
+	}
+
+	err = game.AssignTeam(botName, teamName)
+	if err != nil {
+		delete(game.Players, botName)
+		return "", err
+	}
+
+	return botName, nil
+}
+
 func (game *Game) RemovePlayer(playerName string) error {
 	if game.Phase != Teaming {
 		return errors.New(ErrNotTeaming)
@@ -127,7 +206,7 @@ func (game *Game) AssignTeam(playerName string, teamName string) error {
 	game.Players[playerName] = newPlayer
 
 	if game.canStartBidding() == nil {
-		game.Deck = NewDeck()
+		game.shuffleDeck()
 	}
 
 	return nil
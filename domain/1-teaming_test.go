@@ -228,7 +228,8 @@ func TestStart(test *testing.T) {
 		err := game.Start()
 
 		assert.NoError(err)
-		assert.Equal(Bidding, game.Phase)
+		assert.Equal(Cutting, game.Phase)
+		assert.Equal("P4", game.Dealer)
 		assert.Equal(2, utilities.Abs(game.Players["P1"].Order-game.Players["P2"].Order))
 		assert.Equal(2, utilities.Abs(game.Players["P3"].Order-game.Players["P4"].Order))
 	})
@@ -246,10 +247,86 @@ func TestStart(test *testing.T) {
 		err := game.Start()
 
 		assert.NoError(err)
-		assert.Equal(Bidding, game.Phase)
+		assert.Equal(Cutting, game.Phase)
+		assert.Equal("P1", game.Dealer)
 		assert.Equal(4, game.Players["P1"].Order)
 		assert.Equal(1, game.Players["P2"].Order)
 		assert.Equal(2, game.Players["P3"].Order)
 		assert.Equal(3, game.Players["P4"].Order)
 	})
 }
+
+func TestAddBot(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should add a bot completing the team of a lonely player", func(test *testing.T) {
+		game := newGameWith4Players()
+		delete(game.Players, "P4")
+		game.Players["P1"] = Player{Team: "A"}
+		game.Players["P2"] = Player{Team: "A"}
+		game.Players["P3"] = Player{Team: "B"}
+
+		botName, err := game.AddBot("heuristic", "")
+
+		assert.NoError(err)
+		assert.Equal("Bot 1", botName)
+		assert.Equal("B", game.Players[botName].Team)
+		assert.Equal("heuristic", game.Players[botName].Bot)
+		assert.True(game.Players[botName].IsBot())
+	})
+
+	test.Run("should give a new name to each bot", func(test *testing.T) {
+		game := newGameWith2Players()
+
+		first, err := game.AddBot("random", "")
+		assert.NoError(err)
+		second, err := game.AddBot("random", "")
+		assert.NoError(err)
+
+		assert.Equal("Bot 1", first)
+		assert.Equal("Bot 2", second)
+		assert.Equal("Bots 1", game.Players[first].Team)
+		assert.Equal("Bots 1", game.Players[second].Team)
+	})
+
+	test.Run("should join the given team", func(test *testing.T) {
+		game := newGameWith2Players()
+
+		botName, err := game.AddBot("random", "C")
+
+		assert.NoError(err)
+		assert.Equal("C", game.Players[botName].Team)
+	})
+
+	test.Run("should not seat a bot in a full team", func(test *testing.T) {
+		game := newGameWith2Players()
+		game.Players["P1"] = Player{Team: "A"}
+		game.Players["P2"] = Player{Team: "A"}
+
+		_, err := game.AddBot("random", "A")
+
+		assert.Error(err)
+		assert.Equal(ErrTeamFull, err.Error())
+		assert.Equal(2, len(game.Players))
+	})
+
+	test.Run("should create a new team when every team is full", func(test *testing.T) {
+		game := newGameWith2Players()
+		game.Players["P1"] = Player{Team: "Bots 1"}
+		game.Players["P2"] = Player{Team: "Bots 1"}
+
+		botName, err := game.AddBot("random", "")
+
+		assert.NoError(err)
+		assert.Equal("Bots 2", game.Players[botName].Team)
+	})
+
+	test.Run("should not seat a bot in a full game", func(test *testing.T) {
+		game := newGameWith4Players()
+
+		_, err := game.AddBot("random", "")
+
+		assert.Error(err)
+		assert.Equal(ErrGameFull, err.Error())
+	})
+}
@@ -13,14 +13,26 @@ const (
 	ErrHasBeenCoinched    = "HAS BEEN COINCHED"
 	ErrBiddingItsOwnColor = "BIDDING ITS OWN COLOR"
 	ErrNoBidYet           = "NO BID YET"
+	ErrCoinchingItsOwnBid = "COINCHING ITS OWN BID"
+	ErrNotCoinched        = "NOT COINCHED"
+	ErrHasBeenSurcoinched = "HAS BEEN SURCOINCHED"
+	ErrNotContractTeam    = "NOT THE CONTRACT TEAM"
+	ErrNotCutting         = "NOT IN CUTTING PHASE"
+	ErrInvalidCut         = "INVALID CUT"
 )
 
-func (game *Game) startBidding() error {
+// MIN_CUT is the number of cards that must be left on each side of the cut. RANDOM_CUT lets the source of the game
+// choose the position, for the bots and the players who have run out of time.
+const (
+	MIN_CUT    = 3
+	RANDOM_CUT = 0
+)
+
+func (game *Game) startDeal() error {
 	err := game.canStartBidding()
 	if err != nil {
 		return err
 	}
-	game.Phase = Bidding
 
 	shouldInitiateOrder := false
 
@@ -37,37 +49,70 @@ func (game *Game) startBidding() error {
 		game.rotateInitialOrder()
 	}
 
-	game.distributeCards()
+	game.startCutting()
 
 	return nil
 }
 
-func (game *Game) distributeCards() {
+// startCutting waits for the player on the left of the dealer to cut the deck. This player has the initial order 1,
+// so they are also the first to receive their cards and to bid.
+func (game *Game) startCutting() {
+	game.Phase = Cutting
 	for name, player := range game.Players {
-		player.Hand = game.draw(player.Order)
-		game.Players[name] = player
+		if player.InitialOrder == 4 {
+			game.Dealer = name
+		}
 	}
-	game.Deck = []CardID{}
 }
 
-func (game *Game) draw(order int) []CardID {
-	base := order - 1
+func (game *Game) Cut(player string, position int) error {
+	if game.Phase != Cutting {
+		return errors.New(ErrNotCutting)
+	}
+
+	err := game.checkPlayerTurn(player)
+	if err != nil {
+		return err
+	}
+
+	if position == RANDOM_CUT {
+		position = game.randomCut()
+	}
+
+	if position < MIN_CUT || position > len(game.Deck)-MIN_CUT {
+		return errors.New(ErrInvalidCut)
+	}
 
-	deckIndexes := []int{
-		base*3 + 0,
-		base*3 + 1,
-		base*3 + 2,
+	game.Deck = append(game.Deck[position:], game.Deck[:position]...)
+	game.deal()
 
-		12 + base*2 + 0,
-		12 + base*2 + 1,
+	return nil
+}
 
-		20 + base*3 + 0,
-		20 + base*3 + 1,
-		20 + base*3 + 2,
+func (game *Game) deal() {
+	game.distributeCards()
+	game.Phase = Bidding
+}
+
+func (game *Game) distributeCards() {
+	packets := game.getRules().getDealingPackets()
+	for name, player := range game.Players {
+		player.Hand = game.draw(player.InitialOrder, packets)
+		game.Players[name] = player
 	}
+	game.Deck = []CardID{}
+}
+
+// draw gives the cards of the player at the given seat from the left of the dealer, each packet being dealt around
+// the table before the next one.
+func (game *Game) draw(seat int, packets []int) []CardID {
 	hand := []CardID{}
-	for _, deckIndex := range deckIndexes {
-		hand = append(hand, game.Deck[deckIndex])
+	start := 0
+	for _, size := range packets {
+		for i := 0; i < size; i++ {
+			hand = append(hand, game.Deck[start+(seat-1)*size+i])
+		}
+		start += size * 4
 	}
 
 	return hand
@@ -127,27 +172,54 @@ func (game *Game) initiateOrder() {
 	}
 }
 
-func (game *Game) getLastBid() (Bid, BidValue) {
+// GetLastBid replays the auction to give the highest bid and its value. The passes are only counted since the last
+// bid or coinche, so they tell when the auction is over.
+func GetLastBid(bids []BidAction) (Bid, BidValue) {
+	var lastBid Bid
 	var maxValue BidValue
-	for value := range game.Bids {
-		if value > maxValue {
-			maxValue = value
+
+	for _, action := range bids {
+		switch action.Kind {
+		case PlaceBidAction:
+			lastBid = Bid{Player: action.Player, Color: action.Color}
+			maxValue = action.Value
+		case CoincheAction, SurcoincheAction:
+			lastBid.Coinche++
+			lastBid.Pass = 0
+		case PassAction:
+			lastBid.Pass++
 		}
 	}
-	return game.Bids[maxValue], maxValue
+
+	return lastBid, maxValue
+}
+
+func (game *Game) getLastBid() (Bid, BidValue) {
+	return GetLastBid(game.Bids)
+}
+
+func (game *Game) addBidAction(action BidAction) {
+	action.Sequence = len(game.Bids) + 1
+	game.Bids = append(game.Bids, action)
 }
 
 func (game *Game) PlaceBid(player string, value BidValue, color Color) error {
 	if game.Phase != Bidding {
 		return errors.New(ErrNotBidding)
 	}
+
+	err := game.getRules().checkBid(value, color)
+	if err != nil {
+		return err
+	}
+
 	lastBid, maxValue := game.getLastBid()
 
 	if value <= maxValue {
 		return errors.New(ErrBidTooSmall)
 	}
 
-	err := game.checkPlayerTurn(player)
+	err = game.checkPlayerTurn(player)
 	if err != nil {
 		return err
 	}
@@ -160,11 +232,7 @@ func (game *Game) PlaceBid(player string, value BidValue, color Color) error {
 		return errors.New(ErrBiddingItsOwnColor)
 	}
 
-	game.Bids[value] = Bid{
-		Player:  player,
-		Color:   color,
-		Coinche: 0,
-	}
+	game.addBidAction(BidAction{Kind: PlaceBidAction, Player: player, Value: value, Color: color})
 
 	game.rotateOrder()
 	return nil
@@ -177,24 +245,12 @@ func (game *Game) Pass(player string) error {
 
 	lastBid, maxValue := game.getLastBid()
 
-	if lastBid.Coinche > 0 && lastBid.Pass == 0 { // In this case any player of the team can pass
-		err := game.checkTeamTurn(player)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := game.checkPlayerTurn(player)
-		if err != nil {
-			return err
-		}
+	err := game.checkAnswerTurn(player, lastBid)
+	if err != nil {
+		return err
 	}
 
-	game.Bids[maxValue] = Bid{
-		Player:  lastBid.Player,
-		Color:   lastBid.Color,
-		Coinche: lastBid.Coinche,
-		Pass:    lastBid.Pass + 1,
-	}
+	game.addBidAction(BidAction{Kind: PassAction, Player: player})
 
 	if lastBid.Coinche > 0 {
 		if lastBid.Pass+1 > 1 {
@@ -212,6 +268,16 @@ func (game *Game) Pass(player string) error {
 	}
 
 	if lastBid.Pass+1 > 3 {
+		if maxValue == 0 && game.getRules().SingleDeal {
+			game.passOut()
+			return nil
+		}
+
+		if maxValue == 0 {
+			game.redeal()
+			return nil
+		}
+
 		game.startPlaying()
 		return nil
 	}
@@ -220,6 +286,106 @@ func (game *Game) Pass(player string) error {
 	return nil
 }
 
+type LegalBids struct {
+	Values        []BidValue
+	Colors        []Color
+	CanPass       bool
+	CanCoinche    bool
+	CanSurcoinche bool
+}
+
+var bidColors = []Color{Club, Diamond, Heart, Spade, NoTrump, AllTrump}
+
+// LegalBids lists what the player is allowed to do in the bidding. A coinche is reserved to the team that has not
+// placed the last bid, and a surcoinche to the team that has.
+func (game Game) LegalBids(playerName string) LegalBids {
+	legalBids := LegalBids{Values: []BidValue{}, Colors: []Color{}}
+
+	player, ok := game.Players[playerName]
+	if game.Phase != Bidding || !ok {
+		return legalBids
+	}
+
+	lastBid, maxValue := game.getLastBid()
+	isPlayerTurn := game.checkPlayerTurn(playerName) == nil
+	isTeamTurn := game.checkTeamTurn(playerName) == nil
+	isAnswerTurn := game.checkAnswerTurn(playerName, lastBid) == nil
+	isContractTeam := maxValue > 0 && game.Players[lastBid.Player].Team == player.Team
+
+	legalBids.CanPass = isAnswerTurn
+	legalBids.CanCoinche = isTeamTurn && maxValue > 0 && lastBid.Coinche == 0 && !isContractTeam
+	legalBids.CanSurcoinche = isAnswerTurn && lastBid.Coinche == 1 && isContractTeam
+
+	if !isPlayerTurn || lastBid.Coinche > 0 {
+		return legalBids
+	}
+
+	rules := game.getRules()
+
+	for value := maxValue + 10; value <= Capot; value += 10 {
+		if rules.checkBid(value, Club) == nil {
+			legalBids.Values = append(legalBids.Values, value)
+		}
+	}
+
+	if len(legalBids.Values) == 0 {
+		return legalBids
+	}
+
+	for _, color := range bidColors {
+		if lastBid.Player == playerName && lastBid.Color == color {
+			continue
+		}
+		if rules.checkBid(legalBids.Values[0], color) == nil {
+			legalBids.Colors = append(legalBids.Colors, color)
+		}
+	}
+
+	return legalBids
+}
+
+func (game *Game) gatherHands() []CardID {
+	heap := []CardID{}
+	for order := 1; order <= 4; order++ {
+		for _, player := range game.Players {
+			if player.Order == order {
+				heap = append(heap, player.Hand...)
+			}
+		}
+	}
+	return heap
+}
+
+func (game *Game) redeal() {
+	game.Deck = game.gatherHands()
+	game.Bids = []BidAction{}
+	game.Redeals++
+
+	game.rotateInitialOrder()
+	game.startCutting()
+}
+
+// passOut finishes a single deal on which every player has passed, as it cannot be dealt again.
+func (game *Game) passOut() {
+	game.Scores = map[string]int{}
+	for _, player := range game.Players {
+		game.Scores[player.Team] = 0
+	}
+	game.Phase = Finished
+}
+
+// checkAnswerTurn lets any player of the contract team answer a coinche, until one of them has passed.
+func (game *Game) checkAnswerTurn(player string, lastBid Bid) error {
+	if lastBid.Coinche > 0 && lastBid.Pass == 0 {
+		return game.checkTeamTurn(player)
+	}
+	return game.checkPlayerTurn(player)
+}
+
+func (game *Game) isContractTeam(player string, lastBid Bid) bool {
+	return game.Players[lastBid.Player].Team == game.Players[player].Team
+}
+
 func (game *Game) Coinche(player string) error {
 	if game.Phase != Bidding {
 		return errors.New(ErrNotBidding)
@@ -236,18 +402,47 @@ func (game *Game) Coinche(player string) error {
 		return errors.New(ErrNoBidYet)
 	}
 
-	game.Bids[maxValue] = Bid{
-		Player:  lastBid.Player,
-		Color:   lastBid.Color,
-		Coinche: lastBid.Coinche + 1,
-		Pass:    0,
+	if lastBid.Coinche > 0 {
+		return errors.New(ErrHasBeenCoinched)
 	}
 
-	if lastBid.Coinche+1 > 2 {
-		game.startPlaying()
+	if game.isContractTeam(player, lastBid) {
+		return errors.New(ErrCoinchingItsOwnBid)
 	}
 
+	game.addBidAction(BidAction{Kind: CoincheAction, Player: player})
 	game.rotateOrder()
 
 	return nil
 }
+
+// Surcoinche answers a coinche, and closes the bidding as nothing can follow it.
+func (game *Game) Surcoinche(player string) error {
+	if game.Phase != Bidding {
+		return errors.New(ErrNotBidding)
+	}
+
+	lastBid, _ := game.getLastBid()
+
+	if lastBid.Coinche == 0 {
+		return errors.New(ErrNotCoinched)
+	}
+
+	if lastBid.Coinche > 1 {
+		return errors.New(ErrHasBeenSurcoinched)
+	}
+
+	if !game.isContractTeam(player, lastBid) {
+		return errors.New(ErrNotContractTeam)
+	}
+
+	err := game.checkAnswerTurn(player, lastBid)
+	if err != nil {
+		return err
+	}
+
+	game.addBidAction(BidAction{Kind: SurcoincheAction, Player: player})
+	game.startPlaying()
+
+	return nil
+}
@@ -17,11 +17,26 @@ func newTeamingGame() Game {
 			"P4": {Team: "even"},
 		},
 		Phase: Teaming,
-		Bids:  make(map[BidValue]Bid),
+		Bids:  []BidAction{},
 		Deck:  NewDeck(),
 	}
 }
 
+// newCuttingGame has a deck which is sorted once cut at 3 cards.
+func newCuttingGame() Game {
+	game := newBiddingGame()
+	game.Phase = Cutting
+	game.Dealer = "P4"
+	game.Deck = []CardID{
+		SQ, SK, SA,
+		C7, C8, C9, C10, CJ, CQ, CK, CA,
+		D7, D8, D9, D10, DJ, DQ, DK, DA,
+		H7, H8, H9, H10, HJ, HQ, HK, HA,
+		S7, S8, S9, S10, SJ,
+	}
+	return game
+}
+
 func newBiddingGame() Game {
 	return Game{
 		ID:   2,
@@ -33,7 +48,7 @@ func newBiddingGame() Game {
 			"P4": {Team: "even", Order: 4, InitialOrder: 4},
 		},
 		Phase: Bidding,
-		Bids:  make(map[BidValue]Bid),
+		Bids:  []BidAction{},
 		Deck:  []CardID{},
 	}
 }
@@ -42,19 +57,14 @@ func TestBidding(test *testing.T) {
 	assert := assert.New(test)
 
 	test.Run("should distribute as expected", func(test *testing.T) {
-		game := newTeamingGame()
-		game.Deck = []CardID{
-			C7, C8, C9, C10, CJ, CQ, CK, CA,
-			D7, D8, D9, D10, DJ, DQ, DK, DA,
-			H7, H8, H9, H10, HJ, HQ, HK, HA,
-			S7, S8, S9, S10, SJ, SQ, SK, SA,
-		}
+		game := newCuttingGame()
 
-		err := game.Start()
+		err := game.Cut("P1", 3)
 		if err != nil {
 			test.Fatal(err)
 		}
 
+		assert.Equal(Bidding, game.Phase)
 		assert.Equal([]CardID{}, game.Deck)
 		assert.Equal([]CardID{C7, C8, C9, DJ, DQ, HJ, HQ, HK}, game.Players["P1"].Hand)
 		assert.Equal([]CardID{C10, CJ, CQ, DK, DA, HA, S7, S8}, game.Players["P2"].Hand)
@@ -62,6 +72,22 @@ func TestBidding(test *testing.T) {
 		assert.Equal([]CardID{D8, D9, D10, H9, H10, SQ, SK, SA}, game.Players["P4"].Hand)
 	})
 
+	test.Run("should distribute with the 3-3-2 pattern", func(test *testing.T) {
+		game := newCuttingGame()
+		game.Rules = ClassicRules
+		game.Rules.DealingPattern = ThreeThreeTwo
+
+		err := game.Cut("P1", 3)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal([]CardID{C7, C8, C9, DJ, DQ, DK, S7, S8}, game.Players["P1"].Hand)
+		assert.Equal([]CardID{C10, CJ, CQ, DA, H7, H8, S9, S10}, game.Players["P2"].Hand)
+		assert.Equal([]CardID{CK, CA, D7, H9, H10, HJ, SJ, SQ}, game.Players["P3"].Hand)
+		assert.Equal([]CardID{D8, D9, D10, HQ, HK, HA, SK, SA}, game.Players["P4"].Hand)
+	})
+
 	test.Run("should fail if not in bidding", func(test *testing.T) {
 		teamingGame := Game{ID: 2, Phase: Teaming}
 
@@ -85,15 +111,15 @@ func TestBidding(test *testing.T) {
 
 		err := game.PlaceBid("P1", Eighty, Spade)
 
-		want := Bid{Player: "P1", Color: Spade}
+		want := []BidAction{{Sequence: 1, Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Spade}}
 		assert.NoError(err)
-		assert.Equal(want, game.Bids[Eighty])
+		assert.Equal(want, game.Bids)
 	})
 
 	test.Run("should place another bid", func(test *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: Spade},
+		game.Bids = []BidAction{
+			{Sequence: 1, Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Spade},
 		}
 		game.Players = map[string]Player{
 			"P1": {Order: 4},
@@ -104,15 +130,16 @@ func TestBidding(test *testing.T) {
 
 		err := game.PlaceBid("P2", Ninety, Club)
 
-		want := Bid{Player: "P2", Color: Club}
+		want := BidAction{Sequence: 2, Kind: PlaceBidAction, Player: "P2", Value: Ninety, Color: Club}
 		assert.NoError(err)
-		assert.Equal(want, game.Bids[Ninety])
+		assert.Equal(2, len(game.Bids))
+		assert.Equal(want, game.Bids[1])
 	})
 
 	test.Run("should fail if placing a bid smaller or equal to previous bid", func(test *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: Spade},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Spade},
 		}
 		game.Players = map[string]Player{
 			"P1": {Order: 3},
@@ -151,8 +178,8 @@ func TestBidding(test *testing.T) {
 
 	test.Run("should fail if the player bid on its own color", func(test *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: Spade},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Spade},
 		}
 
 		err := game.PlaceBid("P1", HundredAndTen, Spade)
@@ -162,13 +189,75 @@ func TestBidding(test *testing.T) {
 	})
 }
 
+func TestCut(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should let the player on the left of the dealer cut", func(test *testing.T) {
+		game := newCuttingGame()
+
+		err := game.Cut("P2", 16)
+
+		assert.Error(err)
+		assert.Equal(ErrNotYourTurn+" P2 2", err.Error())
+
+		err = game.Cut("P1", 11)
+
+		assert.NoError(err)
+		assert.Equal(D7, game.Players["P1"].Hand[0])
+	})
+
+	test.Run("should leave a few cards on each side of the cut", func(test *testing.T) {
+		for _, position := range []int{-1, MIN_CUT - 1, 32 - MIN_CUT + 1} {
+			game := newCuttingGame()
+
+			err := game.Cut("P1", position)
+
+			assert.Error(err)
+			assert.Equal(ErrInvalidCut, err.Error())
+		}
+	})
+
+	test.Run("should fail to cut outside of the cutting phase", func(test *testing.T) {
+		game := newBiddingGame()
+
+		err := game.Cut("P1", 16)
+
+		assert.Error(err)
+		assert.Equal(ErrNotCutting, err.Error())
+	})
+
+	test.Run("should give valid random cuts", func(test *testing.T) {
+		game := newCuttingGame()
+		for i := 0; i < 100; i++ {
+			position := game.randomCut()
+
+			assert.GreaterOrEqual(position, MIN_CUT)
+			assert.LessOrEqual(position, 32-MIN_CUT)
+		}
+	})
+
+	test.Run("should cut at random with the source of the game", func(test *testing.T) {
+		game := newCuttingGame()
+		game.Seed = 42
+		other := newCuttingGame()
+		other.Seed = 42
+
+		assert.NoError(game.Cut("P1", RANDOM_CUT))
+		assert.NoError(other.Cut("P1", RANDOM_CUT))
+
+		assert.Equal(1, game.Draws)
+		assert.Equal(game.Hands(), other.Hands())
+	})
+}
+
 func TestCoinche(test *testing.T) {
 	assert := assert.New(test)
 
 	test.Run("same team player should not be able to coinche", func(test *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: Spade, Coinche: 1},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Spade},
+			{Kind: CoincheAction},
 		}
 		game.Players = map[string]Player{
 			"P1": {Order: 4},
@@ -188,17 +277,158 @@ func TestCoinche(test *testing.T) {
 		assert.Equal(ErrNotYourTeamTurn, err.Error())
 	})
 
-	test.Run("should be able to coinche several times", func(t *testing.T) {
+	test.Run("should not coinche its own team bid", func(test *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P4", Color: Spade},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P3", Value: Eighty, Color: Spade},
+		}
+
+		err := game.Coinche("P1")
+
+		assert.Error(err)
+		assert.Equal(ErrCoinchingItsOwnBid, err.Error())
+	})
+
+	test.Run("should not coinche twice", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
 		}
 
 		err := game.Coinche("P1")
 		assert.NoError(err)
 
 		err = game.Coinche("P4")
+
+		assert.Error(err)
+		assert.Equal(ErrHasBeenCoinched, err.Error())
+	})
+}
+
+func TestSurcoinche(test *testing.T) {
+	assert := assert.New(test)
+
+	newCoinchedGame := func() Game {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Sequence: 1, Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
+		}
+		err := game.Coinche("P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+		return game
+	}
+
+	test.Run("should let any player of the contract team surcoinche", func(test *testing.T) {
+		for _, name := range []string{"P2", "P4"} {
+			game := newCoinchedGame()
+
+			err := game.Surcoinche(name)
+
+			assert.NoError(err)
+			assert.Equal(Playing, game.Phase)
+			assert.Equal(BidAction{Sequence: 3, Kind: SurcoincheAction, Player: name}, game.Bids[2])
+		}
+	})
+
+	test.Run("should fail to surcoinche a bid that was not coinched", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P3", Value: Eighty, Color: Spade},
+		}
+
+		err := game.Surcoinche("P1")
+
+		assert.Error(err)
+		assert.Equal(ErrNotCoinched, err.Error())
+	})
+
+	test.Run("should fail to surcoinche the other team bid", func(test *testing.T) {
+		game := newCoinchedGame()
+
+		err := game.Surcoinche("P3")
+
+		assert.Error(err)
+		assert.Equal(ErrNotContractTeam, err.Error())
+	})
+
+	test.Run("should fail to surcoinche twice", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P3", Value: Eighty, Color: Spade},
+			{Kind: CoincheAction, Player: "P2"},
+			{Kind: SurcoincheAction, Player: "P1"},
+		}
+
+		err := game.Surcoinche("P1")
+
+		assert.Error(err)
+		assert.Equal(ErrHasBeenSurcoinched, err.Error())
+	})
+
+	test.Run("should only let the partner surcoinche once a player of the team passed", func(test *testing.T) {
+		game := newCoinchedGame()
+		err := game.Pass("P2")
 		assert.NoError(err)
+
+		err = game.Surcoinche("P2")
+
+		assert.Error(err)
+		assert.Equal(ErrNotYourTurn+" P2 3", err.Error())
+
+		err = game.Surcoinche("P4")
+
+		assert.NoError(err)
+		assert.Equal(Playing, game.Phase)
+	})
+}
+
+func TestAuctionHistory(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should keep the passes placed before the first bid", func(test *testing.T) {
+		game := newBiddingGame()
+
+		assert.NoError(game.Pass("P1"))
+		assert.NoError(game.PlaceBid("P2", Eighty, Heart))
+		assert.NoError(game.Coinche("P3"))
+
+		assert.Equal([]BidAction{
+			{Sequence: 1, Kind: PassAction, Player: "P1"},
+			{Sequence: 2, Kind: PlaceBidAction, Player: "P2", Value: Eighty, Color: Heart},
+			{Sequence: 3, Kind: CoincheAction, Player: "P3"},
+		}, game.Bids)
+	})
+
+	test.Run("should record the surcoinche after the coinche", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Sequence: 1, Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
+		}
+
+		assert.NoError(game.Coinche("P1"))
+		assert.NoError(game.Surcoinche("P4"))
+
+		lastBid, _ := GetLastBid(game.Bids)
+		assert.Equal(CoincheAction, game.Bids[1].Kind)
+		assert.Equal(SurcoincheAction, game.Bids[2].Kind)
+		assert.Equal(2, lastBid.Coinche)
+	})
+
+	test.Run("should derive the last bid from the history", func(test *testing.T) {
+		lastBid, value := GetLastBid([]BidAction{
+			{Sequence: 1, Kind: PassAction, Player: "P1"},
+			{Sequence: 2, Kind: PlaceBidAction, Player: "P2", Value: Eighty, Color: Heart},
+			{Sequence: 3, Kind: PassAction, Player: "P3"},
+			{Sequence: 4, Kind: PlaceBidAction, Player: "P4", Value: Ninety, Color: Club},
+			{Sequence: 5, Kind: PassAction, Player: "P1"},
+			{Sequence: 6, Kind: CoincheAction, Player: "P2"},
+			{Sequence: 7, Kind: PassAction, Player: "P4"},
+		})
+
+		assert.Equal(Ninety, value)
+		assert.Equal(Bid{Player: "P4", Color: Club, Coinche: 1, Pass: 1}, lastBid)
 	})
 }
 
@@ -207,26 +437,69 @@ func TestEndOfBidding(test *testing.T) {
 
 	test.Run("should start playing after two passes after coinche", func(t *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P4", Color: Spade, Coinche: 2},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
 		}
 
-		err := game.Pass("P1")
+		err := game.Coinche("P1")
 		assert.NoError(err)
 
-		err = game.Pass("P3")
+		err = game.Pass("P2")
+		assert.NoError(err)
+		assert.Equal(Bidding, game.Phase)
+
+		err = game.Pass("P4")
 		assert.NoError(err)
 
 		assert.Equal(Playing, game.Phase)
 	})
 
-	test.Run("should start playing after 3 coinches", func(t *testing.T) {
+	test.Run("should let the partner pass first after coinche", func(t *testing.T) {
 		game := newBiddingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P4", Color: Spade, Coinche: 2},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
 		}
 
 		err := game.Coinche("P1")
+		assert.NoError(err)
+
+		err = game.Pass("P4")
+		assert.NoError(err)
+
+		err = game.Pass("P4")
+		assert.Error(err)
+		assert.Equal(ErrNotYourTurn+" P4 3", err.Error())
+
+		err = game.Pass("P2")
+		assert.NoError(err)
+
+		assert.Equal(Playing, game.Phase)
+	})
+
+	test.Run("should not let the coinching team pass after coinche", func(t *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
+		}
+
+		err := game.Coinche("P1")
+		assert.NoError(err)
+
+		err = game.Pass("P3")
+
+		assert.Error(err)
+		assert.Equal(ErrNotYourTeamTurn, err.Error())
+	})
+
+	test.Run("should start playing after a surcoinche", func(t *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
+			{Kind: CoincheAction, Player: "P1"},
+		}
+		game.rotateOrder()
+
+		err := game.Surcoinche("P2")
 
 		assert.NoError(err)
 		assert.Equal(Playing, game.Phase)
@@ -240,9 +513,9 @@ func TestEndOfBidding(test *testing.T) {
 			"P3": {Team: "odd", Order: 1, InitialOrder: 3},
 			"P4": {Team: "even", Order: 2, InitialOrder: 4},
 		}
-		game.Bids = map[BidValue]Bid{
-			Eighty:        {Player: "P1", Color: Club},
-			HundredAndTen: {Player: "P2", Color: Spade},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Club},
+			{Kind: PlaceBidAction, Player: "P2", Value: HundredAndTen, Color: Spade},
 		}
 
 		err := game.Pass("P3")
@@ -256,4 +529,183 @@ func TestEndOfBidding(test *testing.T) {
 
 		assert.Equal(Playing, game.Phase)
 	})
+	test.Run("should redeal after 4 passes without any bid", func(t *testing.T) {
+		game := newTeamingGame()
+		err := game.Start()
+		if err != nil {
+			test.Fatal(err)
+		}
+		err = game.Cut("P1", RANDOM_CUT)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		previousHands := map[string][]CardID{}
+		for name, player := range game.Players {
+			previousHands[name] = player.Hand
+		}
+
+		err = game.Pass("P1")
+		assert.NoError(err)
+		err = game.Pass("P2")
+		assert.NoError(err)
+		err = game.Pass("P3")
+		assert.NoError(err)
+		err = game.Pass("P4")
+		assert.NoError(err)
+
+		assert.Equal(Cutting, game.Phase)
+		assert.Equal(0, len(game.Bids))
+		assert.Equal(1, game.Redeals)
+		assert.Equal(32, len(game.Deck))
+		assert.Equal("P1", game.Dealer)
+
+		assert.Equal(1, game.Players["P2"].Order)
+		assert.Equal(1, game.Players["P2"].InitialOrder)
+		assert.Equal(4, game.Players["P1"].Order)
+
+		err = game.Cut("P2", RANDOM_CUT)
+		assert.NoError(err)
+
+		assert.Equal(Bidding, game.Phase)
+		assert.Equal([]CardID{}, game.Deck)
+
+		allCards := map[CardID]bool{}
+		for _, player := range game.Players {
+			assert.Equal(8, len(player.Hand))
+			for _, card := range player.Hand {
+				allCards[card] = true
+			}
+		}
+		assert.Equal(32, len(allCards))
+	})
+
+	test.Run("should not redeal after 4 passes following a bid", func(t *testing.T) {
+		game := newBiddingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P4", Value: Eighty, Color: Spade},
+		}
+
+		for _, name := range []string{"P1", "P2", "P3", "P4"} {
+			err := game.Pass(name)
+			assert.NoError(err)
+		}
+
+		assert.Equal(Playing, game.Phase)
+		assert.Equal(0, game.Redeals)
+	})
+
+	test.Run("redeals should be reset for the next game", func(t *testing.T) {
+		game := newNormalGame()
+		game.Redeals = 2
+
+		game.resetForNextGame()
+
+		assert.Equal(0, game.Redeals)
+	})
+}
+
+func TestLegalBids(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should allow every bid to the first player", func(test *testing.T) {
+		game := newBiddingGame()
+
+		got := game.LegalBids("P1")
+
+		assert.Equal([]BidValue{Eighty, Ninety, Hundred, HundredAndTen, HundredAndTwenty, HundredAndThirty, HundredAndFourty, HundredAndFifty, Capot}, got.Values)
+		assert.Equal(bidColors, got.Colors)
+		assert.True(got.CanPass)
+		assert.False(got.CanCoinche)
+		assert.False(got.CanSurcoinche)
+	})
+
+	test.Run("should not allow anything when it is not the player turn", func(test *testing.T) {
+		game := newBiddingGame()
+
+		got := game.LegalBids("P2")
+
+		assert.Equal(LegalBids{Values: []BidValue{}, Colors: []Color{}}, got)
+	})
+
+	test.Run("should only allow bigger bids and coinche to the opponents", func(test *testing.T) {
+		game := newBiddingGame()
+		err := game.PlaceBid("P1", HundredAndFifty, Spade)
+		assert.NoError(err)
+
+		got := game.LegalBids("P2")
+
+		assert.Equal([]BidValue{Capot}, got.Values)
+		assert.True(got.CanPass)
+		assert.True(got.CanCoinche)
+
+		got = game.LegalBids("P4")
+
+		assert.Equal([]BidValue{}, got.Values)
+		assert.False(got.CanPass)
+		assert.True(got.CanCoinche)
+	})
+
+	test.Run("should not allow the partner of the bidder to coinche", func(test *testing.T) {
+		game := newBiddingGame()
+		err := game.PlaceBid("P1", Eighty, Spade)
+		assert.NoError(err)
+		err = game.Pass("P2")
+		assert.NoError(err)
+
+		got := game.LegalBids("P3")
+
+		assert.True(got.CanPass)
+		assert.False(got.CanCoinche)
+	})
+
+	test.Run("should not allow the bidder to bid its own color again", func(test *testing.T) {
+		game := newBiddingGame()
+		err := game.PlaceBid("P1", Eighty, Spade)
+		assert.NoError(err)
+		for _, name := range []string{"P2", "P3", "P4"} {
+			err = game.Pass(name)
+			assert.NoError(err)
+		}
+
+		got := game.LegalBids("P1")
+
+		assert.NotContains(got.Colors, Spade)
+		assert.Contains(got.Colors, Heart)
+	})
+
+	test.Run("should follow the rules colors", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Rules = Rules{Name: "custom", MinBid: Hundred}
+
+		got := game.LegalBids("P1")
+
+		assert.Equal(Hundred, got.Values[0])
+		assert.Equal([]Color{Club, Diamond, Heart, Spade}, got.Colors)
+	})
+
+	test.Run("should only allow surcoinche to the contract team after a coinche", func(test *testing.T) {
+		game := newBiddingGame()
+		err := game.PlaceBid("P1", Eighty, Spade)
+		assert.NoError(err)
+		err = game.Coinche("P2")
+		assert.NoError(err)
+
+		got := game.LegalBids("P3")
+
+		assert.Equal([]BidValue{}, got.Values)
+		assert.True(got.CanPass)
+		assert.False(got.CanCoinche)
+		assert.True(got.CanSurcoinche)
+
+		got = game.LegalBids("P1")
+
+		assert.True(got.CanPass)
+		assert.True(got.CanSurcoinche)
+
+		got = game.LegalBids("P4")
+
+		assert.False(got.CanPass)
+		assert.False(got.CanSurcoinche)
+	})
 }
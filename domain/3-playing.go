@@ -10,6 +10,7 @@ const (
 	ErrShouldPlayAskedColor  = "SHOULD PLAY ASKED COLOR"
 	ErrShouldPlayBiggerTrump = "SHOULD PLAY BIGGER TRUMP"
 	ErrShouldPlayTrump       = "SHOULD PLAY TRUMP"
+	ErrNoLegalCard           = "NO LEGAL CARD"
 )
 
 func (game *Game) startPlaying() {
@@ -90,7 +91,7 @@ func (game Game) isPartnerWinner(team string) bool {
 	if playCount < 2 {
 		return false
 	}
-	winnerTeam := game.Players[lastTurn.getWinner(trump)].Team
+	winnerTeam := game.Players[lastTurn.GetWinner(trump)].Team
 	return winnerTeam == team
 }
 
@@ -158,6 +159,41 @@ func (game *Game) canPlayCard(card CardID, playerName string) error {
 	return errors.New(ErrShouldPlayTrump)
 }
 
+func (game Game) LegalCards(playerName string) []CardID {
+	legalCards := []CardID{}
+
+	if game.Phase != Playing || game.checkPlayerTurn(playerName) != nil {
+		return legalCards
+	}
+
+	for _, card := range game.Players[playerName].Hand {
+		if game.canPlayCard(card, playerName) == nil {
+			legalCards = append(legalCards, card)
+		}
+	}
+
+	return legalCards
+}
+
+// LowestLegalCard returns the legal card giving the fewest points, the weakest one between cards of equal points.
+func (game Game) LowestLegalCard(playerName string) (CardID, error) {
+	legalCards := game.LegalCards(playerName)
+	if len(legalCards) == 0 {
+		return "", errors.New(ErrNoLegalCard)
+	}
+
+	trump := game.trump()
+	lowest := legalCards[0]
+	for _, card := range legalCards[1:] {
+		if card.Points(trump) < lowest.Points(trump) ||
+			(card.Points(trump) == lowest.Points(trump) && card.Strength(trump) < lowest.Strength(trump)) {
+			lowest = card
+		}
+	}
+
+	return lowest, nil
+}
+
 func (game *Game) createTurn(newPlay Play) {
 	game.Turns = append(game.Turns, Turn{
 		Plays: []Play{newPlay},
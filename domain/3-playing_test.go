@@ -18,10 +18,10 @@ func TestStartPlaying(test *testing.T) {
 			S7, S8, S9, S10, SJ, SQ, SK, SA,
 		}
 
-		game.Bids = map[BidValue]Bid{
-			Eighty:  {Player: "P1", Color: Club},
-			Ninety:  {Player: "P2", Color: Diamond},
-			Hundred: {Player: "P3", Color: Heart},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Club},
+			{Kind: PlaceBidAction, Player: "P2", Value: Ninety, Color: Diamond},
+			{Kind: PlaceBidAction, Player: "P3", Value: Hundred, Color: Heart},
 		}
 
 		game.startPlaying()
@@ -41,8 +41,8 @@ func newPlayingGame() Game {
 			"P4": {Team: "even", Order: 4, InitialOrder: 4, Hand: []CardID{D8, D9, D10, H9, H10, SQ, SK, SA}},
 		},
 		Phase: Playing,
-		Bids: map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: Heart},
+		Bids: []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Heart},
 		},
 	}
 }
@@ -287,8 +287,8 @@ func TestCanPlay(test *testing.T) {
 
 	test.Run("in All trump, should fail to play a lower trump while having a bigger trump", func(test *testing.T) {
 		game := newPlayingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: AllTrump},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: AllTrump},
 		}
 		game.Players = map[string]Player{
 			"P1": {Team: "odd", Order: 1, InitialOrder: 1, Hand: []CardID{H9}},
@@ -310,8 +310,8 @@ func TestCanPlay(test *testing.T) {
 
 	test.Run("in All trump, should be able to play a lower trump if no bigger trump", func(test *testing.T) {
 		game := newPlayingGame()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {Player: "P1", Color: AllTrump},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: AllTrump},
 		}
 		game.Players = map[string]Player{
 			"P1": {Team: "odd", Order: 1, InitialOrder: 1, Hand: []CardID{H9}},
@@ -377,3 +377,64 @@ func TestEndOfPlayingPhase(test *testing.T) {
 		assert.Equal(Counting, game.Phase)
 	})
 }
+
+func TestLegalCards(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should allow the whole hand to the first player", func(test *testing.T) {
+		game := newPlayingGame()
+
+		assert.Equal(game.Players["P1"].Hand, game.LegalCards("P1"))
+	})
+
+	test.Run("should not allow any card when it is not the player turn", func(test *testing.T) {
+		game := newPlayingGame()
+
+		assert.Equal([]CardID{}, game.LegalCards("P2"))
+	})
+
+	test.Run("should only allow the asked color", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Play("P1", C7)
+		assert.NoError(err)
+
+		assert.Equal([]CardID{C10, CJ, CQ}, game.LegalCards("P2"))
+	})
+
+	test.Run("should only allow trumps when the asked color is missing", func(test *testing.T) {
+		game := newPlayingGame()
+		game.Players["P1"] = Player{Team: "odd", Order: 1, InitialOrder: 1, Hand: []CardID{S7}}
+		game.Players["P2"] = Player{Team: "even", Order: 2, InitialOrder: 2, Hand: []CardID{DK, H10, HA}}
+
+		err := game.Play("P1", S7)
+		assert.NoError(err)
+
+		assert.Equal([]CardID{H10, HA}, game.LegalCards("P2"))
+	})
+}
+
+func TestLowestLegalCard(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should give the legal card with the fewest points", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Play("P1", C7)
+		assert.NoError(err)
+
+		got, err := game.LowestLegalCard("P2")
+
+		assert.NoError(err)
+		assert.Equal(CJ, got)
+	})
+
+	test.Run("should fail when it is not the player turn", func(test *testing.T) {
+		game := newPlayingGame()
+
+		_, err := game.LowestLegalCard("P2")
+
+		assert.Error(err)
+		assert.Equal(ErrNoLegalCard, err.Error())
+	})
+}
@@ -1,7 +1,7 @@
 package domain
 
 import (
-	"math/rand"
+	"errors"
 )
 
 const (
@@ -13,6 +13,8 @@ func (game *Game) end() {
 	game.Phase = Counting
 
 	game.calculatesTeamPointsAndScores()
+
+	game.finishIfTargetReached()
 }
 
 func (game Game) getPlayersWithTrumpQueenAndKing() map[Color]string {
@@ -100,11 +102,12 @@ func (game *Game) applyLastTen() {
 
 func (game *Game) applyAllTrumpNoTrump(contractTeam string) {
 	trump := game.trump()
+	rules := game.getRules()
 
 	if trump == NoTrump {
-		game.Points[contractTeam] = game.Points[contractTeam] * 162 / 130 // converting to int automatically rounds down which is what we want because we use >= to check if contract is fulfilled
+		game.Points[contractTeam] = rules.NoTrumpRatio.apply(game.Points[contractTeam])
 	} else if trump == AllTrump {
-		game.Points[contractTeam] = game.Points[contractTeam] * 162 / 258
+		game.Points[contractTeam] = rules.AllTrumpRatio.apply(game.Points[contractTeam])
 	}
 }
 
@@ -126,6 +129,8 @@ func (game *Game) calculatesTeamPoints() (contractTeamPointsWithoutBelote int, o
 
 	game.applyBeloteToPoints(contractTeam)
 
+	game.applyDeclarationsToPoints(contractTeam)
+
 	return contractTeamPointsWithoutBelote, otherTeamPointsWithoutBelote
 }
 
@@ -168,8 +173,9 @@ func roundToClosestMultipleOfTen(number int) int {
 
 func (game *Game) addRealizedPoints(isCapot bool, isContractWon bool, coinche int, contractTeamPointsWithoutBelote int, otherTeamPointsWithoutBelote int) {
 	contractTeam, otherTeam := game.getTeams()
+	rules := game.getRules()
 
-	isCoinche := coinche > 0
+	isCoinche := coinche > 0 || rules.AllOrNothing
 	isCapotWon := isCapot && game.Points[otherTeam] == 0
 	isCapotLost := isCapot && game.Points[otherTeam] != 0
 	isNormalContractWon := !isCapot && isContractWon
@@ -178,19 +184,19 @@ func (game *Game) addRealizedPoints(isCapot bool, isContractWon bool, coinche in
 	isNormalContractLostWithCoinche := isNormalContractLost && isCoinche
 
 	if isCapotWon {
-		game.Scores[contractTeam] += CAPO_WON_SCORE
+		game.Scores[contractTeam] += rules.CapotWonScore
 	} else if isCapotLost {
-		game.Scores[otherTeam] += CAPO_LOST_SCORE
+		game.Scores[otherTeam] += rules.CapotLostScore
 	} else if isNormalContractWonWithCoinche {
-		game.Scores[contractTeam] += 160
+		game.Scores[contractTeam] += rules.CoincheScore
 	} else if isNormalContractLostWithCoinche {
-		game.Scores[otherTeam] += 160
+		game.Scores[otherTeam] += rules.CoincheScore
 	} else if isNormalContractWon {
-		roundedContractTeamPoints := roundToClosestMultipleOfTen(contractTeamPointsWithoutBelote)
+		roundedContractTeamPoints := rules.roundRealizedPoints(contractTeamPointsWithoutBelote)
 		game.Scores[contractTeam] += roundedContractTeamPoints
-		game.Scores[otherTeam] += (160 - roundedContractTeamPoints)
+		game.Scores[otherTeam] += (rules.DealScore - roundedContractTeamPoints)
 	} else {
-		game.Scores[otherTeam] += 160
+		game.Scores[otherTeam] += rules.DealScore
 	}
 }
 
@@ -216,6 +222,8 @@ func (game *Game) calculatesTeamScores(contractTeamPointsWithoutBelote int, othe
 
 	game.applyBeloteToScores()
 
+	game.applyDeclarationsToScores()
+
 	coinche := lastBid.Coinche
 
 	game.addRealizedPoints(isCapot, isContractWon, coinche, contractTeamPointsWithoutBelote, otherTeamPointsWithoutBelote)
@@ -263,9 +271,10 @@ func (game Game) getPlayersPlayedCards() map[string][]CardID {
 	return playersCards
 }
 
+// getScoreWithCoinche doubles the score for a coinche, then doubles it again for a surcoinche.
 func getScoreWithCoinche(score int, coinche int) int {
-	if coinche > 0 {
-		return score * 2 * coinche
+	for i := 0; i < coinche; i++ {
+		score *= 2
 	}
 
 	return score
@@ -279,23 +288,28 @@ func getNewDeck(turns []Turn) []CardID {
 		}
 	}
 
-	cutPoint := rand.Intn(len(heap))
-	return append(heap[cutPoint:], heap[:cutPoint]...)
+	return heap
 }
 
 func (game *Game) resetForNextGame() {
 	game.Points = map[string]int{}
 	game.Phase = Teaming
-	game.Bids = map[BidValue]Bid{}
+	game.Bids = []BidAction{}
 	game.Deck = getNewDeck(game.Turns)
 	game.Turns = []Turn{}
+	game.Redeals = 0
+	game.Declarations = []Declaration{}
 }
 
 func (game *Game) Start() error {
+	if game.IsFinished() {
+		return errors.New(ErrMatchFinished)
+	}
+
 	if game.Phase == Counting {
 		game.resetForNextGame()
 	}
 
-	err := game.startBidding()
+	err := game.startDeal()
 	return err
 }
@@ -17,13 +17,8 @@ func newNormalGame() Game {
 			"P4": {Team: "even", Order: 1, InitialOrder: 4, Hand: []CardID{SA}},
 		},
 		Phase: Counting,
-		Bids: map[BidValue]Bid{
-			Eighty: {
-				Player:  "P1",
-				Color:   Heart,
-				Coinche: 0,
-				Pass:    0,
-			},
+		Bids: []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Heart},
 		},
 		Scores: map[string]int{
 			"odd":  0,
@@ -96,13 +91,8 @@ func newGameWithBelote() Game {
 
 func newGameWithNoTrump() Game {
 	game := newNormalGame()
-	game.Bids = map[BidValue]Bid{
-		Eighty: {
-			Player:  "P1",
-			Color:   NoTrump,
-			Coinche: 0,
-			Pass:    0,
-		},
+	game.Bids = []BidAction{
+		{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: NoTrump},
 	}
 	game.Turns = []Turn{
 		{[]Play{
@@ -159,13 +149,8 @@ func newGameWithNoTrump() Game {
 
 func newGameWithAllTrump() Game {
 	game := newNormalGame()
-	game.Bids = map[BidValue]Bid{
-		Eighty: {
-			Player:  "P2",
-			Color:   AllTrump,
-			Coinche: 0,
-			Pass:    0,
-		},
+	game.Bids = []BidAction{
+		{Kind: PlaceBidAction, Player: "P2", Value: Eighty, Color: AllTrump},
 	}
 	game.Turns = []Turn{
 		{[]Play{
@@ -222,13 +207,8 @@ func newGameWithAllTrump() Game {
 
 func newGameWithCapotLost() Game {
 	game := newNormalGame()
-	game.Bids = map[BidValue]Bid{
-		Capot: {
-			Player:  "P2",
-			Color:   Heart,
-			Coinche: 0,
-			Pass:    0,
-		},
+	game.Bids = []BidAction{
+		{Kind: PlaceBidAction, Player: "P2", Value: Capot, Color: Heart},
 	}
 	game.Turns = []Turn{
 		{[]Play{
@@ -378,13 +358,9 @@ func TestCounting(test *testing.T) {
 
 	test.Run("should count correctly in a WON game with COINCHE and BELOTE (for odd team)", func(test *testing.T) {
 		game := newGameWithBelote()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {
-				Player:  "P1",
-				Color:   Heart,
-				Coinche: 1,
-				Pass:    0,
-			},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Heart},
+			{Kind: CoincheAction},
 		}
 
 		game.calculatesTeamPointsAndScores()
@@ -435,13 +411,10 @@ func TestCounting(test *testing.T) {
 
 	test.Run("should count correctly in a game with ALL-TRUMP with SURCOINCHE", func(test *testing.T) {
 		game := newGameWithAllTrump()
-		game.Bids = map[BidValue]Bid{
-			Eighty: {
-				Player:  "P2",
-				Color:   AllTrump,
-				Coinche: 2,
-				Pass:    0,
-			},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P2", Value: Eighty, Color: AllTrump},
+			{Kind: CoincheAction},
+			{Kind: SurcoincheAction},
 		}
 
 		game.calculatesTeamPointsAndScores()
@@ -472,6 +445,93 @@ func TestCounting(test *testing.T) {
 	})
 }
 
+func TestCountingWithDeclarations(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should count correctly in a WON game with BELOTE and a TIERCE (for odd team)", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+		}
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(99+20+20, game.Points["odd"])
+		assert.Equal(63, game.Points["even"])
+
+		assert.Equal((80 + 100 + 20 + 20), game.Scores["odd"])
+		assert.Equal(60, game.Scores["even"])
+	})
+
+	test.Run("should only count the declarations of the team with the best declaration", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+			{Player: "P2", Cards: []CardID{C10, CJ, CQ}},
+		}
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(99+20, game.Points["odd"])
+		assert.Equal(63, game.Points["even"])
+
+		assert.Equal((80 + 100 + 20), game.Scores["odd"])
+		assert.Equal(60+20, game.Scores["even"])
+	})
+
+	test.Run("declarations should help the contract team to fulfill its contract", func(test *testing.T) {
+		game := newNormalGame()
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+		}
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(72+20, game.Points["odd"])
+		assert.Equal(90, game.Points["even"])
+
+		assert.Equal(80+20+70, game.Scores["odd"])
+		assert.Equal(90, game.Scores["even"])
+	})
+
+	test.Run("declarations of the other team should not count in points", func(test *testing.T) {
+		game := newNormalGame()
+		game.Declarations = []Declaration{
+			{Player: "P2", Cards: []CardID{C10, CJ, CQ}},
+		}
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(72, game.Points["odd"])
+		assert.Equal(90, game.Points["even"])
+
+		assert.Equal(0, game.Scores["odd"])
+		assert.Equal(160+80+20, game.Scores["even"])
+	})
+
+	test.Run("declarations should be multiplied by the COINCHE", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Heart},
+			{Kind: CoincheAction},
+		}
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+		}
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal((80+160+20+20)*2, game.Scores["odd"])
+		assert.Equal(0, game.Scores["even"])
+	})
+
+	test.Run("declarations should be cleared for the next game", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+		}
+
+		game.resetForNextGame()
+
+		assert.Equal(0, len(game.Declarations))
+	})
+}
+
 func TestRestarting(test *testing.T) {
 	assert := assert.New(test)
 
@@ -484,6 +544,14 @@ func TestRestarting(test *testing.T) {
 			test.Fatal(err)
 		}
 
+		assert.Equal(Cutting, game.Phase)
+		assert.Equal(32, len(game.Deck))
+
+		err = game.Cut("P2", RANDOM_CUT)
+		if err != nil {
+			test.Fatal(err)
+		}
+
 		assert.Equal(Bidding, game.Phase)
 		assert.Equal(0, len(game.Bids))
 		assert.Equal(0, len(game.Turns))
@@ -521,13 +589,9 @@ func TestRestarting(test *testing.T) {
 		game.Scores["even"] = 1000
 		game.Scores["odd"] = 2000
 
-		game.Bids = map[BidValue]Bid{
-			Eighty: {
-				Player:  "P1",
-				Color:   Heart,
-				Coinche: 1,
-				Pass:    0,
-			},
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Heart},
+			{Kind: CoincheAction},
 		}
 
 		game.calculatesTeamPointsAndScores()
@@ -540,3 +604,13 @@ func TestRestarting(test *testing.T) {
 
 	})
 }
+
+func TestScoreWithCoinche(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should double the score for each coinche level", func(test *testing.T) {
+		assert.Equal(160, getScoreWithCoinche(160, 0))
+		assert.Equal(320, getScoreWithCoinche(160, 1))
+		assert.Equal(640, getScoreWithCoinche(160, 2))
+	})
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+)
+
+const (
+	ErrMatchFinished = "MATCH IS FINISHED"
+	ErrInvalidTarget = "INVALID TARGET"
+)
+
+const DEFAULT_TARGET = 1000
+
+var targets = []int{1000, 1500, 2000}
+
+func checkTarget(target int) error {
+	for _, validTarget := range targets {
+		if target == validTarget {
+			return nil
+		}
+	}
+
+	return errors.New(ErrInvalidTarget)
+}
+
+func (game *Game) SetTarget(target int) error {
+	if game.Phase != Teaming {
+		return errors.New(ErrNotTeaming)
+	}
+
+	err := checkTarget(target)
+	if err != nil {
+		return err
+	}
+
+	game.Target = target
+	return nil
+}
+
+// getMatchWinner returns the team with the highest score once a team has reached the target.
+// If both teams have exactly the same score, there is no winner yet and another deal is played.
+// A single deal has no target, its winner is the team with the highest score.
+func (game Game) getMatchWinner() string {
+	target := game.Target
+	if game.getRules().SingleDeal {
+		target = 0
+	} else if target == 0 {
+		return ""
+	}
+
+	winner := ""
+	bestScore := 0
+	isTie := false
+
+	for team, score := range game.Scores {
+		if score < target {
+			continue
+		}
+
+		if score > bestScore {
+			winner = team
+			bestScore = score
+			isTie = false
+		} else if score == bestScore {
+			isTie = true
+		}
+	}
+
+	if isTie {
+		return ""
+	}
+
+	return winner
+}
+
+// finishIfTargetReached always finishes a single deal, without winner when both teams have the same score.
+func (game *Game) finishIfTargetReached() {
+	winner := game.getMatchWinner()
+	if winner == "" && !game.getRules().SingleDeal {
+		return
+	}
+
+	game.Winner = winner
+	game.Phase = Finished
+}
+
+func (game Game) IsFinished() bool {
+	return game.Phase == Finished
+}
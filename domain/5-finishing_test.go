@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTarget(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("new game should have the default target", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		assert.Equal(1000, game.Target)
+	})
+
+	test.Run("should be able to choose a target", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		err := game.SetTarget(2000)
+
+		assert.NoError(err)
+		assert.Equal(2000, game.Target)
+	})
+
+	test.Run("should fail with an invalid target", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		err := game.SetTarget(1200)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidTarget, err.Error())
+		assert.Equal(1000, game.Target)
+	})
+
+	test.Run("should fail to change the target once started", func(test *testing.T) {
+		game := newBiddingGame()
+
+		err := game.SetTarget(1500)
+
+		assert.Error(err)
+		assert.Equal(ErrNotTeaming, err.Error())
+	})
+}
+
+func TestFinishing(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should not finish without target", func(test *testing.T) {
+		game := newNormalGame()
+		game.Scores["even"] = 5000
+
+		game.end()
+
+		assert.Equal(Counting, game.Phase)
+		assert.Equal("", game.Winner)
+	})
+
+	test.Run("should not finish when no team reached the target", func(test *testing.T) {
+		game := newNormalGame()
+		game.Target = 1000
+		game.Scores["even"] = 700
+
+		game.end()
+
+		assert.Equal(Counting, game.Phase)
+		assert.Equal(700+160+80, game.Scores["even"])
+		assert.Equal("", game.Winner)
+	})
+
+	test.Run("should finish when a team reaches the target", func(test *testing.T) {
+		game := newNormalGame()
+		game.Target = 1000
+		game.Scores["even"] = 800
+
+		game.end()
+
+		assert.Equal(Finished, game.Phase)
+		assert.Equal("even", game.Winner)
+	})
+
+	test.Run("should give the victory to the highest score when both teams reach the target", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Target = 1000
+		game.Scores["odd"] = 900
+		game.Scores["even"] = 990
+
+		game.end()
+
+		assert.Equal(900+80+100+20, game.Scores["odd"])
+		assert.Equal(990+60, game.Scores["even"])
+		assert.Equal(Finished, game.Phase)
+		assert.Equal("odd", game.Winner)
+	})
+
+	test.Run("should play another deal when both teams have the same score above the target", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Target = 1000
+		game.Scores["odd"] = 950
+		game.Scores["even"] = 1090
+
+		game.end()
+
+		assert.Equal(1150, game.Scores["odd"])
+		assert.Equal(1150, game.Scores["even"])
+		assert.Equal(Counting, game.Phase)
+		assert.Equal("", game.Winner)
+	})
+
+	test.Run("should finish a single deal whatever the target", func(test *testing.T) {
+		game := newNormalGame()
+		game.Rules = ClassicRules
+		game.Rules.SingleDeal = true
+
+		game.end()
+
+		assert.Equal(Finished, game.Phase)
+		assert.Equal("even", game.Winner)
+	})
+
+	test.Run("should finish a single deal without winner when both teams have the same score", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Rules = ClassicRules
+		game.Rules.SingleDeal = true
+		game.Scores["odd"] = 0
+		game.Scores["even"] = 140
+
+		game.end()
+
+		assert.Equal(game.Scores["odd"], game.Scores["even"])
+		assert.Equal(Finished, game.Phase)
+		assert.Equal("", game.Winner)
+	})
+
+	test.Run("should not be able to start a new deal once finished", func(test *testing.T) {
+		game := newNormalGame()
+		game.Target = 1000
+		game.Scores["even"] = 800
+		game.end()
+
+		err := game.Start()
+
+		assert.Error(err)
+		assert.Equal(ErrMatchFinished, err.Error())
+		assert.Equal(Finished, game.Phase)
+	})
+}
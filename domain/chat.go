@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	ErrEmptyMessage    = "EMPTY MESSAGE"
+	ErrMessageTooLong  = "MESSAGE TOO LONG"
+	ErrUnknownReaction = "UNKNOWN REACTION"
+	ErrNotInGame       = "NOT IN GAME"
+)
+
+const (
+	MAX_CHAT_LENGTH   = 280
+	CHAT_HISTORY_SIZE = 50
+)
+
+type ChatKind string
+
+const (
+	TextChat     ChatKind = "text"
+	ReactionChat ChatKind = "reaction"
+)
+
+var reactions = map[string]bool{
+	"thumbsUp":   true,
+	"thumbsDown": true,
+	"laugh":      true,
+	"clap":       true,
+	"surprised":  true,
+	"sad":        true,
+	"angry":      true,
+	"heart":      true,
+}
+
+type ChatMessage struct {
+	Sender    string
+	Kind      ChatKind
+	Content   string
+	CreatedAt time.Time
+}
+
+func NewChatMessage(sender string, text string) (ChatMessage, error) {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return ChatMessage{}, errors.New(ErrEmptyMessage)
+	}
+
+	if len([]rune(text)) > MAX_CHAT_LENGTH {
+		return ChatMessage{}, errors.New(ErrMessageTooLong)
+	}
+
+	return ChatMessage{Sender: sender, Kind: TextChat, Content: text, CreatedAt: time.Now()}, nil
+}
+
+func NewReaction(sender string, reaction string) (ChatMessage, error) {
+	if !reactions[reaction] {
+		return ChatMessage{}, errors.New(ErrUnknownReaction)
+	}
+
+	return ChatMessage{Sender: sender, Kind: ReactionChat, Content: reaction, CreatedAt: time.Now()}, nil
+}
+
+// CanChat is true for the players and the spectators of the game.
+func (game Game) CanChat(name string) bool {
+	_, ok := game.Players[name]
+	return ok || game.isSpectator(name)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChat(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should create a trimmed text message", func(test *testing.T) {
+		got, err := NewChatMessage("P1", "  well played  ")
+
+		assert.NoError(err)
+		assert.Equal("P1", got.Sender)
+		assert.Equal(TextChat, got.Kind)
+		assert.Equal("well played", got.Content)
+	})
+
+	test.Run("should refuse an empty message", func(test *testing.T) {
+		_, err := NewChatMessage("P1", "   ")
+
+		assert.Error(err)
+		assert.Equal(ErrEmptyMessage, err.Error())
+	})
+
+	test.Run("should refuse a too long message", func(test *testing.T) {
+		_, err := NewChatMessage("P1", strings.Repeat("é", MAX_CHAT_LENGTH+1))
+
+		assert.Error(err)
+		assert.Equal(ErrMessageTooLong, err.Error())
+	})
+
+	test.Run("should accept a message of the maximum length", func(test *testing.T) {
+		_, err := NewChatMessage("P1", strings.Repeat("é", MAX_CHAT_LENGTH))
+
+		assert.NoError(err)
+	})
+
+	test.Run("should create a known reaction", func(test *testing.T) {
+		got, err := NewReaction("P1", "clap")
+
+		assert.NoError(err)
+		assert.Equal(ReactionChat, got.Kind)
+		assert.Equal("clap", got.Content)
+	})
+
+	test.Run("should refuse an unknown reaction", func(test *testing.T) {
+		_, err := NewReaction("P1", "dance")
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownReaction, err.Error())
+	})
+
+	test.Run("should let players and spectators chat", func(test *testing.T) {
+		game := newPlayingGame()
+		_ = game.AddSpectator("S1")
+
+		assert.True(game.CanChat("P1"))
+		assert.True(game.CanChat("S1"))
+		assert.False(game.CanChat("S2"))
+	})
+}
@@ -0,0 +1,350 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+	ErrNotFirstTurn        = "DECLARATIONS ARE ONLY ALLOWED BEFORE PLAYING THE FIRST CARD"
+	ErrInvalidDeclaration  = "INVALID DECLARATION"
+	ErrCardAlreadyDeclared = "CARD ALREADY DECLARED"
+)
+
+type DeclarationKind string
+
+const (
+	Tierce     DeclarationKind = "tierce"
+	Cinquante  DeclarationKind = "cinquante"
+	Cent       DeclarationKind = "cent"
+	CarreJacks DeclarationKind = "carreJacks"
+	CarreNines DeclarationKind = "carreNines"
+	Carre      DeclarationKind = "carre"
+)
+
+var declarationValues = map[DeclarationKind]int{
+	Tierce:     20,
+	Cinquante:  50,
+	Cent:       100,
+	CarreJacks: 200,
+	CarreNines: 150,
+	Carre:      100,
+}
+
+// sequenceRanks is the natural order of the cards used for sequences: 7, 8, 9, 10, jack, queen, king, as.
+var sequenceRanks = map[Strength]int{
+	Seven: 1,
+	Eight: 2,
+	Nine:  3,
+	Ten:   4,
+	Jack:  5,
+	Queen: 6,
+	King:  7,
+	As:    8,
+}
+
+// carreRanks breaks ties between carrés of the same value: as, then 10, then king, then queen.
+var carreRanks = map[Strength]int{
+	Queen: 1,
+	King:  2,
+	Ten:   3,
+	As:    4,
+	Nine:  5,
+	Jack:  6,
+}
+
+type Declaration struct {
+	Player string
+	Cards  []CardID
+}
+
+func getSequenceKind(length int) DeclarationKind {
+	if length >= 5 {
+		return Cent
+	}
+	if length == 4 {
+		return Cinquante
+	}
+	return Tierce
+}
+
+func getCarreKind(strength Strength) DeclarationKind {
+	if strength == Jack {
+		return CarreJacks
+	}
+	if strength == Nine {
+		return CarreNines
+	}
+	return Carre
+}
+
+func isCarre(cardIDs []CardID) bool {
+	if len(cardIDs) != 4 {
+		return false
+	}
+
+	strength := cards[cardIDs[0]].strength
+	if _, ok := carreRanks[strength]; !ok {
+		return false
+	}
+
+	colors := map[Color]bool{}
+	for _, cardID := range cardIDs {
+		card := cards[cardID]
+		if card.strength != strength {
+			return false
+		}
+		colors[card.color] = true
+	}
+
+	return len(colors) == 4
+}
+
+func isSequence(cardIDs []CardID) bool {
+	if len(cardIDs) < 3 {
+		return false
+	}
+
+	color := cards[cardIDs[0]].color
+	ranks := []int{}
+	for _, cardID := range cardIDs {
+		card := cards[cardID]
+		if card.color != color {
+			return false
+		}
+		ranks = append(ranks, sequenceRanks[card.strength])
+	}
+
+	sort.Ints(ranks)
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i] != ranks[i-1]+1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (declaration Declaration) isValid() bool {
+	for _, cardID := range declaration.Cards {
+		if _, ok := cards[cardID]; !ok {
+			return false
+		}
+	}
+
+	return isCarre(declaration.Cards) || isSequence(declaration.Cards)
+}
+
+func (declaration Declaration) isCarre() bool {
+	return isCarre(declaration.Cards)
+}
+
+func (declaration Declaration) Kind() DeclarationKind {
+	if declaration.isCarre() {
+		return getCarreKind(cards[declaration.Cards[0]].strength)
+	}
+	return getSequenceKind(len(declaration.Cards))
+}
+
+func (declaration Declaration) Value() int {
+	return declarationValues[declaration.Kind()]
+}
+
+func (declaration Declaration) topRank() int {
+	if declaration.isCarre() {
+		return carreRanks[cards[declaration.Cards[0]].strength]
+	}
+
+	topRank := 0
+	for _, cardID := range declaration.Cards {
+		rank := sequenceRanks[cards[cardID].strength]
+		if rank > topRank {
+			topRank = rank
+		}
+	}
+	return topRank
+}
+
+func (declaration Declaration) isTrumpSequence(trump Color) bool {
+	if declaration.isCarre() {
+		return false
+	}
+	return trump == AllTrump || cards[declaration.Cards[0]].color == trump
+}
+
+// compareDeclarations returns a positive number if a is better than b, a negative one if b is better and 0 if they are equivalent.
+func compareDeclarations(a Declaration, b Declaration, trump Color) int {
+	if a.Value() != b.Value() {
+		return a.Value() - b.Value()
+	}
+
+	if a.isCarre() != b.isCarre() {
+		if a.isCarre() {
+			return 1
+		}
+		return -1
+	}
+
+	if a.topRank() != b.topRank() {
+		return a.topRank() - b.topRank()
+	}
+
+	if a.isTrumpSequence(trump) != b.isTrumpSequence(trump) {
+		if a.isTrumpSequence(trump) {
+			return 1
+		}
+		return -1
+	}
+
+	return 0
+}
+
+func (game Game) hasPlayedFirstCard(playerName string) bool {
+	if len(game.Turns) == 0 {
+		return false
+	}
+
+	if len(game.Turns) > 1 {
+		return true
+	}
+
+	for _, play := range game.Turns[0].Plays {
+		if play.PlayerName == playerName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (game Game) isAlreadyDeclared(newDeclaration Declaration) bool {
+	for _, declaration := range game.Declarations {
+		if declaration.Player != newDeclaration.Player || declaration.isCarre() != newDeclaration.isCarre() {
+			continue
+		}
+
+		for _, card := range declaration.Cards {
+			for _, newCard := range newDeclaration.Cards {
+				if card == newCard {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (game *Game) Declare(playerName string, cardIDs []CardID) error {
+	if game.Phase != Playing {
+		return errors.New(ErrNotPlaying)
+	}
+
+	player, ok := game.Players[playerName]
+	if !ok {
+		return errors.New(ErrPlayerNotFound)
+	}
+
+	if game.hasPlayedFirstCard(playerName) {
+		return errors.New(ErrNotFirstTurn)
+	}
+
+	declaration := Declaration{Player: playerName, Cards: cardIDs}
+
+	if !declaration.isValid() {
+		return errors.New(ErrInvalidDeclaration)
+	}
+
+	for _, card := range cardIDs {
+		if !player.hasCard(card) {
+			return errors.New(ErrCardNotInHand)
+		}
+	}
+
+	if game.isAlreadyDeclared(declaration) {
+		return errors.New(ErrCardAlreadyDeclared)
+	}
+
+	game.Declarations = append(game.Declarations, declaration)
+
+	return nil
+}
+
+func (game Game) getTeamsBestDeclarations() map[string]Declaration {
+	trump := game.trump()
+	bestDeclarations := map[string]Declaration{}
+
+	for _, declaration := range game.Declarations {
+		team := game.Players[declaration.Player].Team
+		best, ok := bestDeclarations[team]
+		if !ok || compareDeclarations(declaration, best, trump) > 0 {
+			bestDeclarations[team] = declaration
+		}
+	}
+
+	return bestDeclarations
+}
+
+// getDeclarationsWinnerTeam returns the team with the best declaration. When the best declarations of both teams
+// are equivalent, no team wins.
+func (game Game) getDeclarationsWinnerTeam() string {
+	trump := game.trump()
+	winnerTeam := ""
+	var winnerDeclaration Declaration
+	isTie := false
+
+	for team, declaration := range game.getTeamsBestDeclarations() {
+		if winnerTeam == "" {
+			winnerTeam = team
+			winnerDeclaration = declaration
+			continue
+		}
+
+		comparison := compareDeclarations(declaration, winnerDeclaration, trump)
+		if comparison > 0 {
+			winnerTeam = team
+			winnerDeclaration = declaration
+			isTie = false
+		} else if comparison == 0 {
+			isTie = true
+		}
+	}
+
+	if isTie {
+		return ""
+	}
+
+	return winnerTeam
+}
+
+func (game Game) getDeclarationsPoints() (string, int) {
+	winnerTeam := game.getDeclarationsWinnerTeam()
+	if winnerTeam == "" {
+		return "", 0
+	}
+
+	points := 0
+	for _, declaration := range game.Declarations {
+		if game.Players[declaration.Player].Team == winnerTeam {
+			points += declaration.Value()
+		}
+	}
+
+	return winnerTeam, points
+}
+
+func (game *Game) applyDeclarationsToPoints(contractTeam string) {
+	team, points := game.getDeclarationsPoints()
+
+	if team != "" && team == contractTeam {
+		game.Points[team] += points
+	}
+}
+
+func (game *Game) applyDeclarationsToScores() {
+	team, points := game.getDeclarationsPoints()
+
+	if team != "" {
+		game.Scores[team] += points
+	}
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type declarationTest struct {
+	cards []CardID
+	kind  DeclarationKind
+	value int
+}
+
+func TestDeclarationKinds(test *testing.T) {
+	assert := assert.New(test)
+
+	declarationTests := []declarationTest{
+		{[]CardID{C7, C8, C9}, Tierce, 20},
+		{[]CardID{HQ, HJ, HK}, Tierce, 20},
+		{[]CardID{S9, S10, SJ, SQ}, Cinquante, 50},
+		{[]CardID{D10, DJ, DQ, DK, DA}, Cent, 100},
+		{[]CardID{D7, D8, D9, D10, DJ, DQ, DK, DA}, Cent, 100},
+		{[]CardID{CJ, DJ, HJ, SJ}, CarreJacks, 200},
+		{[]CardID{C9, D9, H9, S9}, CarreNines, 150},
+		{[]CardID{CA, DA, HA, SA}, Carre, 100},
+		{[]CardID{CQ, DQ, HQ, SQ}, Carre, 100},
+	}
+
+	for _, t := range declarationTests {
+		test.Run("kind and value should be correct", func(test *testing.T) {
+			declaration := Declaration{Player: "P1", Cards: t.cards}
+
+			assert.True(declaration.isValid())
+			assert.Equal(t.kind, declaration.Kind())
+			assert.Equal(t.value, declaration.Value())
+		})
+	}
+
+	invalidDeclarations := [][]CardID{
+		{C7, C8},
+		{C7, C8, C10},
+		{C7, C8, D9},
+		{C7, D7, H7, S7},
+		{C8, D8, H8, S8},
+		{CJ, DJ, HJ},
+		{CJ, DJ, HJ, "jack-moon"},
+	}
+
+	for _, cards := range invalidDeclarations {
+		test.Run("declaration should be invalid", func(test *testing.T) {
+			declaration := Declaration{Player: "P1", Cards: cards}
+
+			assert.False(declaration.isValid())
+		})
+	}
+}
+
+func TestDeclare(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should be able to declare a sequence", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Declare("P1", []CardID{C7, C8, C9})
+
+		assert.NoError(err)
+		assert.Equal([]Declaration{{Player: "P1", Cards: []CardID{C7, C8, C9}}}, game.Declarations)
+	})
+
+	test.Run("should be able to declare several sequences", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Declare("P1", []CardID{C7, C8, C9})
+		assert.NoError(err)
+
+		err = game.Declare("P1", []CardID{HJ, HQ, HK})
+		assert.NoError(err)
+
+		assert.Equal(2, len(game.Declarations))
+	})
+
+	test.Run("should fail if not in playing phase", func(test *testing.T) {
+		game := newBiddingGame()
+
+		err := game.Declare("P1", []CardID{C7, C8, C9})
+
+		assert.Error(err)
+		assert.Equal(ErrNotPlaying, err.Error())
+	})
+
+	test.Run("should fail if the declaration is invalid", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Declare("P1", []CardID{C7, C9, DJ})
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidDeclaration, err.Error())
+	})
+
+	test.Run("should fail if the cards are not in hand", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Declare("P2", []CardID{C7, C8, C9})
+
+		assert.Error(err)
+		assert.Equal(ErrCardNotInHand, err.Error())
+	})
+
+	test.Run("should fail to declare the same cards twice", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Declare("P1", []CardID{C7, C8, C9})
+		assert.NoError(err)
+
+		err = game.Declare("P1", []CardID{C7, C8, C9})
+
+		assert.Error(err)
+		assert.Equal(ErrCardAlreadyDeclared, err.Error())
+	})
+
+	test.Run("should fail to declare after having played the first card", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Play("P1", C10)
+		assert.Error(err)
+
+		err = game.Play("P1", HK)
+		assert.NoError(err)
+
+		err = game.Declare("P1", []CardID{C7, C8, C9})
+
+		assert.Error(err)
+		assert.Equal(ErrNotFirstTurn, err.Error())
+	})
+
+	test.Run("other players can still declare during the first turn", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Play("P1", HK)
+		assert.NoError(err)
+
+		err = game.Declare("P2", []CardID{C10, CJ, CQ})
+
+		assert.NoError(err)
+	})
+}
+
+func TestCompareDeclarations(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("bigger value should win", func(test *testing.T) {
+		tierce := Declaration{Player: "P1", Cards: []CardID{CJ, CQ, CK}}
+		cinquante := Declaration{Player: "P2", Cards: []CardID{D7, D8, D9, D10}}
+
+		assert.Less(compareDeclarations(tierce, cinquante, Heart), 0)
+		assert.Greater(compareDeclarations(cinquante, tierce, Heart), 0)
+	})
+
+	test.Run("carre should win over a sequence of the same value", func(test *testing.T) {
+		cent := Declaration{Player: "P1", Cards: []CardID{D10, DJ, DQ, DK, DA}}
+		carre := Declaration{Player: "P2", Cards: []CardID{CQ, DQ, HQ, SQ}}
+
+		assert.Greater(compareDeclarations(carre, cent, Diamond), 0)
+	})
+
+	test.Run("highest card should win with the same value", func(test *testing.T) {
+		lowTierce := Declaration{Player: "P1", Cards: []CardID{H7, H8, H9}}
+		highTierce := Declaration{Player: "P2", Cards: []CardID{CJ, CQ, CK}}
+
+		assert.Greater(compareDeclarations(highTierce, lowTierce, Heart), 0)
+	})
+
+	test.Run("carre of as should win over carre of kings", func(test *testing.T) {
+		kings := Declaration{Player: "P1", Cards: []CardID{CK, DK, HK, SK}}
+		aces := Declaration{Player: "P2", Cards: []CardID{CA, DA, HA, SA}}
+
+		assert.Greater(compareDeclarations(aces, kings, Heart), 0)
+	})
+
+	test.Run("trump sequence should win with the same value and highest card", func(test *testing.T) {
+		trumpTierce := Declaration{Player: "P1", Cards: []CardID{H7, H8, H9}}
+		otherTierce := Declaration{Player: "P2", Cards: []CardID{C7, C8, C9}}
+
+		assert.Greater(compareDeclarations(trumpTierce, otherTierce, Heart), 0)
+		assert.Equal(0, compareDeclarations(trumpTierce, otherTierce, NoTrump))
+	})
+}
+
+func TestDeclarationsWinner(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("team with the best declaration should score all its declarations", func(test *testing.T) {
+		game := newPlayingGame()
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+			{Player: "P3", Cards: []CardID{S9, S10, SJ}},
+			{Player: "P2", Cards: []CardID{C10, CJ, CQ}},
+			{Player: "P4", Cards: []CardID{D8, D9, D10}},
+		}
+
+		team, points := game.getDeclarationsPoints()
+
+		assert.Equal("even", team)
+		assert.Equal(40, points)
+	})
+
+	test.Run("no team should score when best declarations are equivalent", func(test *testing.T) {
+		game := newPlayingGame()
+		game.Bids = []BidAction{
+			{Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: NoTrump},
+		}
+		game.Declarations = []Declaration{
+			{Player: "P1", Cards: []CardID{C7, C8, C9}},
+			{Player: "P2", Cards: []CardID{S7, S8, S9}},
+		}
+
+		team, points := game.getDeclarationsPoints()
+
+		assert.Equal("", team)
+		assert.Equal(0, points)
+	})
+
+	test.Run("no team should score without declaration", func(test *testing.T) {
+		game := newPlayingGame()
+
+		team, points := game.getDeclarationsPoints()
+
+		assert.Equal("", team)
+		assert.Equal(0, points)
+	})
+}
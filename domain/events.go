@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ErrUnknownEvent = "UNKNOWN EVENT"
+)
+
+type EventKind string
+
+const (
+	CreateEvent     EventKind = "create"
+	JoinEvent       EventKind = "join"
+	LeaveEvent      EventKind = "leave"
+	JoinTeamEvent   EventKind = "joinTeam"
+	LeaveTeamEvent  EventKind = "leaveTeam"
+	AddBotEvent     EventKind = "addBot"
+	StartEvent      EventKind = "start"
+	CutEvent        EventKind = "cut"
+	BidEvent        EventKind = "bid"
+	PassEvent       EventKind = "pass"
+	CoincheEvent    EventKind = "coinche"
+	SurcoincheEvent EventKind = "surcoinche"
+	PlayEvent       EventKind = "play"
+	DeclareEvent    EventKind = "declare"
+	DealBoardEvent  EventKind = "dealBoard"
+)
+
+// EventPayload only holds the fields used by the kind of the event. Hands are the cards dealt by the event, they are
+// kept because the deck is shuffled randomly.
+type EventPayload struct {
+	Name     string              `json:",omitempty"`
+	Target   int                 `json:",omitempty"`
+	Rules    string              `json:",omitempty"`
+	Dealing  DealingPattern      `json:",omitempty"`
+	Seed     int64               `json:",omitempty"`
+	Team     string              `json:",omitempty"`
+	Bot      string              `json:",omitempty"`
+	Value    BidValue            `json:",omitempty"`
+	Color    Color               `json:",omitempty"`
+	Card     CardID              `json:",omitempty"`
+	Cards    []CardID            `json:",omitempty"`
+	Position int                 `json:",omitempty"`
+	Hands    map[string][]CardID `json:",omitempty"`
+	Seats    []Seat              `json:",omitempty"`
+	Dealer   string              `json:",omitempty"`
+}
+
+type Event struct {
+	Sequence  int
+	Kind      EventKind
+	Player    string
+	Payload   EventPayload
+	CreatedAt time.Time
+}
+
+func NewEvent(kind EventKind, player string, payload EventPayload) Event {
+	return Event{
+		Kind:      kind,
+		Player:    player,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ViewFor only keeps the hand of the given player and hides the seed, from which every deal of the game could be
+// guessed.
+func (event Event) ViewFor(playerName string) Event {
+	view := event
+	view.Payload.Seed = 0
+
+	if len(event.Payload.Hands) > 0 {
+		view.Payload.Hands = map[string][]CardID{}
+		if hand, ok := event.Payload.Hands[playerName]; ok {
+			view.Payload.Hands[playerName] = append([]CardID{}, hand...)
+		}
+	}
+
+	return view
+}
+
+func (game Game) Hands() map[string][]CardID {
+	hands := map[string][]CardID{}
+	for name, player := range game.Players {
+		hands[name] = append([]CardID{}, player.Hand...)
+	}
+	return hands
+}
+
+func (game *Game) setHands(hands map[string][]CardID) {
+	for name, hand := range hands {
+		player := game.Players[name]
+		player.Hand = append([]CardID{}, hand...)
+		game.Players[name] = player
+	}
+}
+
+func (game *Game) create(payload EventPayload) error {
+	*game = NewGameWithSeed(payload.Name, payload.Seed)
+
+	if payload.Target != 0 {
+		err := game.SetTarget(payload.Target)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := game.SetRules(payload.Rules)
+	if err != nil {
+		return err
+	}
+
+	return game.SetDealingPattern(payload.Dealing)
+}
+
+// setDealtHands also deals the cards for the events recorded before the cut, which came with the hands.
+func (game *Game) setDealtHands(hands map[string][]CardID) {
+	if len(hands) == 0 {
+		return
+	}
+	if game.Phase == Cutting {
+		game.deal()
+	}
+	game.setHands(hands)
+}
+
+func (game *Game) Apply(event Event) error {
+	payload := event.Payload
+
+	switch event.Kind {
+	case CreateEvent:
+		return game.create(payload)
+	case JoinEvent:
+		return game.AddPlayer(event.Player)
+	case LeaveEvent:
+		return game.RemovePlayer(event.Player)
+	case JoinTeamEvent:
+		return game.AssignTeam(event.Player, payload.Team)
+	case LeaveTeamEvent:
+		return game.ClearTeam(event.Player)
+	case AddBotEvent:
+		_, err := game.AddBot(payload.Bot, payload.Team)
+		return err
+	case StartEvent:
+		err := game.Start()
+		if err != nil {
+			return err
+		}
+		game.setDealtHands(payload.Hands)
+		return nil
+	case DealBoardEvent:
+		err := game.dealBoard(payload.Seats, payload.Dealer)
+		if err != nil {
+			return err
+		}
+		game.setHands(payload.Hands)
+		return nil
+	case CutEvent:
+		err := game.Cut(event.Player, payload.Position)
+		if err != nil {
+			return err
+		}
+		game.setHands(payload.Hands)
+		return nil
+	case BidEvent:
+		return game.PlaceBid(event.Player, payload.Value, payload.Color)
+	case PassEvent:
+		err := game.Pass(event.Player)
+		if err != nil {
+			return err
+		}
+		game.setDealtHands(payload.Hands)
+		return nil
+	case CoincheEvent:
+		return game.Coinche(event.Player)
+	case SurcoincheEvent:
+		return game.Surcoinche(event.Player)
+	case PlayEvent:
+		return game.Play(event.Player, payload.Card)
+	case DeclareEvent:
+		return game.Declare(event.Player, payload.Cards)
+	}
+
+	return errors.New(ErrUnknownEvent)
+}
+
+// Replay rebuilds a game from its events, the first one being its creation.
+func Replay(events []Event) (Game, error) {
+	game := Game{}
+
+	for _, event := range events {
+		err := game.Apply(event)
+		if err != nil {
+			return game, err
+		}
+	}
+
+	return game, nil
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBiddingEvents() []Event {
+	return []Event{
+		{Kind: CreateEvent, Payload: EventPayload{Name: "GAME ONE", Target: 1500, Rules: ContreeFFBRulesName}},
+		{Kind: JoinEvent, Player: "P1"},
+		{Kind: JoinEvent, Player: "P2"},
+		{Kind: JoinEvent, Player: "P3"},
+		{Kind: AddBotEvent, Player: "Bot 1", Payload: EventPayload{Bot: "random", Team: "even"}},
+		{Kind: JoinTeamEvent, Player: "P1", Payload: EventPayload{Team: "odd"}},
+		{Kind: JoinTeamEvent, Player: "P2", Payload: EventPayload{Team: "even"}},
+		{Kind: JoinTeamEvent, Player: "P3", Payload: EventPayload{Team: "odd"}},
+		{Kind: StartEvent},
+		{Kind: CutEvent, Player: "Bot 1", Payload: EventPayload{Position: 12, Hands: map[string][]CardID{
+			"Bot 1": {C7, C8, C9, DJ, DQ, HJ, HQ, HK},
+			"P1":    {C10, CJ, CQ, DK, DA, HA, S7, S8},
+			"P2":    {CK, CA, D7, H7, H8, S9, S10, SJ},
+			"P3":    {D8, D9, D10, H9, H10, SQ, SK, SA},
+		}}},
+	}
+}
+
+func TestReplay(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should rebuild the game with the dealt hands", func(test *testing.T) {
+		game, err := Replay(newBiddingEvents())
+
+		assert.NoError(err)
+		assert.Equal("GAME ONE", game.Name)
+		assert.Equal(1500, game.Target)
+		assert.Equal(ContreeFFBRules, game.Rules)
+		assert.Equal(Bidding, game.Phase)
+		assert.Equal("random", game.Players["Bot 1"].Bot)
+		assert.Equal("even", game.Players["Bot 1"].Team)
+		assert.Equal([]CardID{C10, CJ, CQ, DK, DA, HA, S7, S8}, game.Players["P1"].Hand)
+		assert.Equal(1, game.Players["Bot 1"].Order)
+		assert.Equal("P3", game.Dealer)
+	})
+
+	test.Run("should replay the bidding and the plays", func(test *testing.T) {
+		events := append(newBiddingEvents(),
+			Event{Kind: BidEvent, Player: "Bot 1", Payload: EventPayload{Value: Eighty, Color: Heart}},
+			Event{Kind: PassEvent, Player: "P1"},
+			Event{Kind: PassEvent, Player: "P2"},
+			Event{Kind: PassEvent, Player: "P3"},
+			Event{Kind: PassEvent, Player: "Bot 1"},
+			Event{Kind: DeclareEvent, Player: "Bot 1", Payload: EventPayload{Cards: []CardID{HJ, HQ, HK}}},
+			Event{Kind: PlayEvent, Player: "Bot 1", Payload: EventPayload{Card: C7}},
+			Event{Kind: PlayEvent, Player: "P1", Payload: EventPayload{Card: C10}},
+		)
+
+		game, err := Replay(events)
+
+		assert.NoError(err)
+		assert.Equal(Playing, game.Phase)
+		lastBid, value := GetLastBid(game.Bids)
+		assert.Equal(Bid{Player: "Bot 1", Color: Heart, Pass: 4}, lastBid)
+		assert.Equal(Eighty, value)
+		assert.Equal(5, len(game.Bids))
+		assert.Equal(1, len(game.Declarations))
+		assert.Equal([]Play{{PlayerName: "Bot 1", Card: C7}, {PlayerName: "P1", Card: C10}}, game.Turns[0].Plays)
+		assert.Equal(1, game.Players["P2"].Order)
+	})
+
+	test.Run("should replay a redeal with the new hands", func(test *testing.T) {
+		hands := map[string][]CardID{
+			"Bot 1": {D8, D9, D10, H9, H10, SQ, SK, SA},
+			"P1":    {C7, C8, C9, DJ, DQ, HJ, HQ, HK},
+			"P2":    {C10, CJ, CQ, DK, DA, HA, S7, S8},
+			"P3":    {CK, CA, D7, H7, H8, S9, S10, SJ},
+		}
+		events := append(newBiddingEvents(),
+			Event{Kind: PassEvent, Player: "Bot 1"},
+			Event{Kind: PassEvent, Player: "P1"},
+			Event{Kind: PassEvent, Player: "P2"},
+			Event{Kind: PassEvent, Player: "P3"},
+			Event{Kind: CutEvent, Player: "P1", Payload: EventPayload{Position: 20, Hands: hands}},
+		)
+
+		game, err := Replay(events)
+
+		assert.NoError(err)
+		assert.Equal(1, game.Redeals)
+		assert.Equal(Bidding, game.Phase)
+		assert.Equal("Bot 1", game.Dealer)
+		assert.Equal(hands["P1"], game.Players["P1"].Hand)
+	})
+
+	test.Run("should deal the hands of the events recorded before the cut", func(test *testing.T) {
+		events := newBiddingEvents()
+		start := events[len(events)-1]
+		start.Kind = StartEvent
+		start.Player = ""
+		hands := start.Payload.Hands
+		events = append(events[:len(events)-2], start,
+			Event{Kind: PassEvent, Player: "Bot 1"},
+			Event{Kind: PassEvent, Player: "P1"},
+			Event{Kind: PassEvent, Player: "P2"},
+			Event{Kind: PassEvent, Player: "P3", Payload: EventPayload{Hands: hands}},
+		)
+
+		game, err := Replay(events)
+
+		assert.NoError(err)
+		assert.Equal(1, game.Redeals)
+		assert.Equal(Bidding, game.Phase)
+		assert.Equal(hands["P1"], game.Players["P1"].Hand)
+	})
+
+	test.Run("should shuffle the deck from the seed of the game", func(test *testing.T) {
+		game, err := Replay([]Event{{Kind: CreateEvent, Payload: EventPayload{Name: "GAME ONE", Seed: 42}}})
+
+		assert.NoError(err)
+		assert.Equal(int64(42), game.Seed)
+		assert.Equal(NewGameWithSeed("GAME ONE", 42).Deck, game.Deck)
+	})
+
+	test.Run("should fail on a refused command", func(test *testing.T) {
+		events := append(newBiddingEvents(), Event{Kind: PlayEvent, Player: "P1", Payload: EventPayload{Card: C10}})
+
+		_, err := Replay(events)
+
+		assert.Error(err)
+		assert.Equal(ErrNotPlaying, err.Error())
+	})
+
+	test.Run("should fail on an unknown event", func(test *testing.T) {
+		_, err := Replay([]Event{{Kind: "dance"}})
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownEvent, err.Error())
+	})
+}
+
+func TestEventView(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should only keep the hand of the player", func(test *testing.T) {
+		events := newBiddingEvents()
+		cut := events[len(events)-1]
+
+		got := cut.ViewFor("P1")
+
+		assert.Equal(map[string][]CardID{"P1": {C10, CJ, CQ, DK, DA, HA, S7, S8}}, got.Payload.Hands)
+		assert.Equal(4, len(cut.Payload.Hands))
+	})
+
+	test.Run("should hide the seed of the game", func(test *testing.T) {
+		create := Event{Kind: CreateEvent, Payload: EventPayload{Name: "GAME ONE", Seed: 42}}
+
+		got := create.ViewFor("P1")
+
+		assert.Equal(int64(0), got.Payload.Seed)
+		assert.Equal("GAME ONE", got.Payload.Name)
+	})
+}
@@ -3,7 +3,6 @@ package domain
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"time"
 )
 
@@ -14,6 +13,8 @@ const (
 	Bidding  Phase = 2
 	Playing  Phase = 3
 	Counting Phase = 4
+	Finished Phase = 5
+	Cutting  Phase = 6
 )
 
 type BidValue int
@@ -80,6 +81,23 @@ func (card card) getValue(trump Color) int {
 
 type CardID string
 
+func (cardID CardID) Color() Color {
+	return cards[cardID].color
+}
+
+func (cardID CardID) Points(trump Color) int {
+	return cards[cardID].getValue(trump)
+}
+
+func (cardID CardID) Strength(trump Color) Strength {
+	card := cards[cardID]
+	if trump == card.color || trump == AllTrump {
+		return card.TrumpStrength
+	}
+
+	return card.strength
+}
+
 const (
 	C7  CardID = "7-club"
 	C8  CardID = "8-club"
@@ -150,13 +168,12 @@ var cards = map[CardID]card{
 	SA:  {Spade, As, TAs, 11, 11},
 }
 
+// NewDeck gives the cards in order, each game shuffles them with its own source.
 func NewDeck() []CardID {
-	deck := []CardID{C7, C8, C9, C10, CJ, CQ, CK, CA, D7, D8, D9, D10, DJ, DQ, DK, DA, H7, H8, H9, H10, HJ, HQ, HK, HA, S7, S8, S9, S10, SJ, SQ, SK, SA}
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
-	return deck
+	return []CardID{C7, C8, C9, C10, CJ, CQ, CK, CA, D7, D8, D9, D10, DJ, DQ, DK, DA, H7, H8, H9, H10, HJ, HQ, HK, HA, S7, S8, S9, S10, SJ, SQ, SK, SA}
 }
 
+// Bid is the highest bid of an auction, with the coinches and passes which have followed it.
 type Bid struct {
 	Player  string
 	Color   Color
@@ -164,6 +181,24 @@ type Bid struct {
 	Pass    int
 }
 
+type BidActionKind string
+
+const (
+	PlaceBidAction   BidActionKind = "bid"
+	PassAction       BidActionKind = "pass"
+	CoincheAction    BidActionKind = "coinche"
+	SurcoincheAction BidActionKind = "surcoinche"
+)
+
+// BidAction is a step of the auction, whose history is kept in order in Game.Bids.
+type BidAction struct {
+	Sequence int
+	Kind     BidActionKind
+	Player   string
+	Value    BidValue `json:",omitempty"`
+	Color    Color    `json:",omitempty"`
+}
+
 type Play struct {
 	PlayerName string
 	Card       CardID
@@ -174,7 +209,7 @@ type Turn struct {
 	Winner string
 }
 
-func (turn Turn) getWinner(trump Color) string {
+func (turn Turn) GetWinner(trump Color) string {
 	var winner string
 	var strongerValue Strength
 	var firstCard CardID
@@ -194,7 +229,7 @@ func (turn Turn) getWinner(trump Color) string {
 }
 
 func (turn *Turn) setWinner(trump Color) {
-	turn.Winner = turn.getWinner(trump)
+	turn.Winner = turn.GetWinner(trump)
 }
 
 func getCardValue(card CardID, trump Color, firstCard CardID) Strength {
@@ -217,17 +252,27 @@ func getCardValue(card CardID, trump Color, firstCard CardID) Strength {
 }
 
 type Game struct {
-	ID        int
-	Name      string
-	CreatedAt time.Time
-	Players   map[string]Player
-	Phase     Phase
-	Bids      map[BidValue]Bid
-	Deck      []CardID
-	Turns     []Turn
-	Scores    map[string]int
-	Points    map[string]int
-	Root      int
+	ID           int
+	Name         string
+	CreatedAt    time.Time
+	Players      map[string]Player
+	Phase        Phase
+	Bids         []BidAction
+	Deck         []CardID
+	Turns        []Turn
+	Scores       map[string]int
+	Points       map[string]int
+	Root         int
+	Redeals      int
+	Target       int
+	Winner       string
+	Declarations []Declaration
+	Rules        Rules
+	Spectators   []string
+	Version      int
+	Dealer       string
+	Seed         int64
+	Draws        int
 }
 
 type Player struct {
@@ -235,21 +280,61 @@ type Player struct {
 	Order        int
 	InitialOrder int
 	Hand         []CardID
+	Bot          string
 }
 
 func (player Player) CanPlay() bool {
 	return player.Order == 0
 }
 
+func (player Player) IsBot() bool {
+	return player.Bot != ""
+}
+
+// PlayerToAct returns the name of the player expected to cut, bid or play, or an empty string outside of these phases.
+func (game Game) PlayerToAct() string {
+	if game.Phase != Cutting && game.Phase != Bidding && game.Phase != Playing {
+		return ""
+	}
+
+	for name, player := range game.Players {
+		if player.Order == 1 {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// BotToPlay returns the name of the bot expected to act, or an empty string if it is not the turn of a bot.
+func (game Game) BotToPlay() string {
+	name := game.PlayerToAct()
+	if name == "" || !game.Players[name].IsBot() {
+		return ""
+	}
+
+	return name
+}
+
 func NewGame(name string) Game {
-	return Game{
+	return NewGameWithSeed(name, NewSeed())
+}
+
+// NewGameWithSeed replays the deals of a known game, e.g. to reproduce a bug or to give the same cards to every table
+// of a tournament.
+func NewGameWithSeed(name string, seed int64) Game {
+	game := Game{
 		Name:    name,
 		Players: map[string]Player{},
 		Phase:   Teaming,
-		Bids:    map[BidValue]Bid{},
-		Deck:    NewDeck(),
+		Bids:    []BidAction{},
 		Root:    0,
+		Target:  DEFAULT_TARGET,
+		Rules:   ClassicRules,
+		Seed:    seed,
 	}
+	game.shuffleDeck()
+	return game
 }
 
 func (game *Game) checkPlayerTurn(playerName string) error {
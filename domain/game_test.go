@@ -38,3 +38,25 @@ func TestGetCardValue(test *testing.T) {
 		})
 	}
 }
+
+func TestPlayerToAct(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should give the first player while bidding", func(test *testing.T) {
+		game := Game{
+			Phase:   Bidding,
+			Players: map[string]Player{"P1": {Order: 2}, "P2": {Order: 1}},
+		}
+
+		assert.Equal("P2", game.PlayerToAct())
+	})
+
+	test.Run("should give nobody while teaming", func(test *testing.T) {
+		game := Game{
+			Phase:   Teaming,
+			Players: map[string]Player{"P1": {Order: 1}},
+		}
+
+		assert.Equal("", game.PlayerToAct())
+	})
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"time"
+)
+
+// NewSeed draws the seed of a new game from a cryptographic source, so the deals cannot be guessed.
+func NewSeed() int64 {
+	var bytes [8]byte
+	_, err := cryptorand.Read(bytes[:])
+	if err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(bytes[:]))
+}
+
+// mixSeed spreads the seed and the number of the draw with splitmix64, so close seeds give unrelated sources.
+func mixSeed(seed int64, draw int) int64 {
+	x := uint64(seed) + uint64(draw+1)*0x9E3779B97F4A7C15
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	return int64(x ^ (x >> 31))
+}
+
+// nextRand gives the source of the next random draw of the game. Every draw only depends on the seed and on the
+// number of draws made before, so a game created from the same seed deals the same cards.
+func (game *Game) nextRand() *rand.Rand {
+	source := rand.NewSource(mixSeed(game.Seed, game.Draws))
+	game.Draws++
+	return rand.New(source)
+}
+
+func (game *Game) shuffleDeck() {
+	deck := NewDeck()
+	game.nextRand().Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	game.Deck = deck
+}
+
+func (game *Game) randomCut() int {
+	return MIN_CUT + game.nextRand().Intn(len(game.Deck)-2*MIN_CUT+1)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeed(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should shuffle the same deck from the same seed", func(test *testing.T) {
+		game := NewGameWithSeed("GAME ONE", 42)
+		other := NewGameWithSeed("GAME TWO", 42)
+
+		assert.Equal(game.Deck, other.Deck)
+		assert.NotEqual(NewDeck(), game.Deck)
+		assert.Equal(1, game.Draws)
+	})
+
+	test.Run("should shuffle different decks from close seeds", func(test *testing.T) {
+		game := NewGameWithSeed("GAME ONE", 1)
+		other := NewGameWithSeed("GAME TWO", 2)
+
+		assert.NotEqual(game.Deck, other.Deck)
+	})
+
+	test.Run("should not shuffle twice the same way", func(test *testing.T) {
+		game := NewGameWithSeed("GAME ONE", 42)
+		first := game.Deck
+
+		game.shuffleDeck()
+
+		assert.NotEqual(first, game.Deck)
+		assert.Equal(2, game.Draws)
+	})
+
+	test.Run("should draw a new seed for each game", func(test *testing.T) {
+		assert.NotEqual(NewGame("GAME ONE").Seed, NewGame("GAME TWO").Seed)
+	})
+}
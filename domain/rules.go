@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"errors"
+)
+
+const (
+	ErrUnknownRules      = "UNKNOWN RULES"
+	ErrColorNotAllowed   = "COLOR NOT ALLOWED"
+	ErrBidValueNotExists = "BID VALUE DOES NOT EXIST"
+	ErrUnknownDealing    = "UNKNOWN DEALING PATTERN"
+)
+
+const (
+	ClassicRulesName        = "classic"
+	ContreeFFBRulesName     = "contree-ffb"
+	RealizedPointsRulesName = "points-realises"
+)
+
+// DealingPattern gives the sizes of the packets dealt to each player in turn.
+type DealingPattern string
+
+const (
+	ThreeTwoThree DealingPattern = "3-2-3"
+	ThreeThreeTwo DealingPattern = "3-3-2"
+)
+
+var dealingPackets = map[DealingPattern][]int{
+	ThreeTwoThree: {3, 2, 3},
+	ThreeThreeTwo: {3, 3, 2},
+}
+
+type Ratio struct {
+	Numerator   int
+	Denominator int
+}
+
+func (ratio Ratio) apply(value int) int {
+	if ratio.Denominator == 0 {
+		return value
+	}
+	return value * ratio.Numerator / ratio.Denominator // converting to int automatically rounds down which is what we want because we use >= to check if contract is fulfilled
+}
+
+type Rules struct {
+	Name string
+
+	MinBid        BidValue
+	AllowNoTrump  bool
+	AllowAllTrump bool
+
+	CapotWonScore  int
+	CapotLostScore int
+	CoincheScore   int
+	DealScore      int
+
+	AllOrNothing        bool
+	RoundRealizedPoints bool
+
+	NoTrumpRatio  Ratio
+	AllTrumpRatio Ratio
+
+	DealingPattern DealingPattern
+
+	// SingleDeal ends the game after one deal, for the boards of a duplicate tournament.
+	SingleDeal bool `json:",omitempty"`
+}
+
+var ClassicRules = Rules{
+	Name:                ClassicRulesName,
+	MinBid:              Eighty,
+	AllowNoTrump:        true,
+	AllowAllTrump:       true,
+	CapotWonScore:       CAPO_WON_SCORE,
+	CapotLostScore:      CAPO_LOST_SCORE,
+	CoincheScore:        160,
+	DealScore:           160,
+	AllOrNothing:        false,
+	RoundRealizedPoints: true,
+	NoTrumpRatio:        Ratio{162, 130},
+	AllTrumpRatio:       Ratio{162, 258},
+	DealingPattern:      ThreeTwoThree,
+}
+
+// ContreeFFBRules only gives points to the team that wins the contract, as in the federation tournaments.
+var ContreeFFBRules = Rules{
+	Name:                ContreeFFBRulesName,
+	MinBid:              Eighty,
+	AllowNoTrump:        true,
+	AllowAllTrump:       true,
+	CapotWonScore:       250,
+	CapotLostScore:      250,
+	CoincheScore:        160,
+	DealScore:           160,
+	AllOrNothing:        true,
+	RoundRealizedPoints: true,
+	NoTrumpRatio:        Ratio{162, 130},
+	AllTrumpRatio:       Ratio{162, 258},
+	DealingPattern:      ThreeTwoThree,
+}
+
+// RealizedPointsRules gives each team the exact points it has made, without rounding.
+var RealizedPointsRules = Rules{
+	Name:                RealizedPointsRulesName,
+	MinBid:              Eighty,
+	AllowNoTrump:        true,
+	AllowAllTrump:       true,
+	CapotWonScore:       CAPO_WON_SCORE,
+	CapotLostScore:      CAPO_LOST_SCORE,
+	CoincheScore:        160,
+	DealScore:           162,
+	AllOrNothing:        false,
+	RoundRealizedPoints: false,
+	NoTrumpRatio:        Ratio{162, 130},
+	AllTrumpRatio:       Ratio{162, 258},
+	DealingPattern:      ThreeTwoThree,
+}
+
+var rulesPresets = map[string]Rules{
+	ClassicRulesName:        ClassicRules,
+	ContreeFFBRulesName:     ContreeFFBRules,
+	RealizedPointsRulesName: RealizedPointsRules,
+}
+
+func GetRules(name string) (Rules, error) {
+	if name == "" {
+		return ClassicRules, nil
+	}
+
+	rules, ok := rulesPresets[name]
+	if !ok {
+		return Rules{}, errors.New(ErrUnknownRules)
+	}
+
+	return rules, nil
+}
+
+func (game *Game) SetRules(name string) error {
+	if game.Phase != Teaming {
+		return errors.New(ErrNotTeaming)
+	}
+
+	rules, err := GetRules(name)
+	if err != nil {
+		return err
+	}
+
+	game.Rules = rules
+	return nil
+}
+
+// SetDealingPattern keeps the pattern of the rules when none is given.
+func (game *Game) SetDealingPattern(pattern DealingPattern) error {
+	if game.Phase != Teaming {
+		return errors.New(ErrNotTeaming)
+	}
+
+	if pattern == "" {
+		return nil
+	}
+
+	if _, ok := dealingPackets[pattern]; !ok {
+		return errors.New(ErrUnknownDealing)
+	}
+
+	game.Rules.DealingPattern = pattern
+	return nil
+}
+
+// getRules falls back on the classic rules for the games created before the rules were stored.
+func (game Game) getRules() Rules {
+	if game.Rules.Name == "" {
+		return ClassicRules
+	}
+	return game.Rules
+}
+
+func (rules Rules) checkBid(value BidValue, color Color) error {
+	if value < rules.MinBid {
+		return errors.New(ErrBidTooSmall)
+	}
+
+	if value > Capot || value%10 != 0 {
+		return errors.New(ErrBidValueNotExists)
+	}
+
+	switch color {
+	case Club, Diamond, Heart, Spade:
+		return nil
+	case NoTrump:
+		if rules.AllowNoTrump {
+			return nil
+		}
+	case AllTrump:
+		if rules.AllowAllTrump {
+			return nil
+		}
+	}
+
+	return errors.New(ErrColorNotAllowed)
+}
+
+// getDealingPackets falls back on 3-2-3 for the rules stored before the dealing pattern.
+func (rules Rules) getDealingPackets() []int {
+	packets, ok := dealingPackets[rules.DealingPattern]
+	if !ok {
+		return dealingPackets[ThreeTwoThree]
+	}
+	return packets
+}
+
+func (rules Rules) roundRealizedPoints(points int) int {
+	if rules.RoundRealizedPoints {
+		return roundToClosestMultipleOfTen(points)
+	}
+	return points
+}
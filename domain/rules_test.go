@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("new game should have the classic rules", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		assert.Equal(ClassicRules, game.Rules)
+	})
+
+	test.Run("should be able to choose a preset", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		err := game.SetRules(ContreeFFBRulesName)
+
+		assert.NoError(err)
+		assert.Equal(ContreeFFBRules, game.Rules)
+	})
+
+	test.Run("should fail with unknown rules", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		err := game.SetRules("belote-de-comptoir")
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownRules, err.Error())
+		assert.Equal(ClassicRules, game.Rules)
+	})
+
+	test.Run("should fail to change the rules once started", func(test *testing.T) {
+		game := newBiddingGame()
+
+		err := game.SetRules(RealizedPointsRulesName)
+
+		assert.Error(err)
+		assert.Equal(ErrNotTeaming, err.Error())
+	})
+
+	test.Run("game without rules should use the classic rules", func(test *testing.T) {
+		game := newBiddingGame()
+
+		assert.Equal(ClassicRules, game.getRules())
+	})
+
+	test.Run("should be able to choose the dealing pattern", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		err := game.SetDealingPattern(ThreeThreeTwo)
+
+		assert.NoError(err)
+		assert.Equal(ThreeThreeTwo, game.Rules.DealingPattern)
+		assert.Equal([]int{3, 3, 2}, game.getRules().getDealingPackets())
+	})
+
+	test.Run("should fail with an unknown dealing pattern", func(test *testing.T) {
+		game := NewGame("GAME ONE")
+
+		err := game.SetDealingPattern("4-4")
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownDealing, err.Error())
+		assert.Equal(ThreeTwoThree, game.Rules.DealingPattern)
+	})
+
+	test.Run("rules without dealing pattern should deal 3-2-3", func(test *testing.T) {
+		rules := Rules{Name: "stored"}
+
+		assert.Equal([]int{3, 2, 3}, rules.getDealingPackets())
+	})
+}
+
+func TestBiddingWithRules(test *testing.T) {
+	assert := assert.New(test)
+
+	customRules := Rules{
+		Name:          "custom",
+		MinBid:        Ninety,
+		AllowNoTrump:  false,
+		AllowAllTrump: true,
+	}
+
+	test.Run("should fail if the bid is under the minimum bid", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Rules = customRules
+
+		err := game.PlaceBid("P1", Eighty, Spade)
+
+		assert.Error(err)
+		assert.Equal(ErrBidTooSmall, err.Error())
+	})
+
+	test.Run("should fail if the bid value does not exist", func(test *testing.T) {
+		game := newBiddingGame()
+
+		err := game.PlaceBid("P1", BidValue(95), Spade)
+
+		assert.Error(err)
+		assert.Equal(ErrBidValueNotExists, err.Error())
+
+		err = game.PlaceBid("P1", BidValue(170), Spade)
+
+		assert.Error(err)
+		assert.Equal(ErrBidValueNotExists, err.Error())
+	})
+
+	test.Run("should fail if the color is not allowed", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Rules = customRules
+
+		err := game.PlaceBid("P1", Ninety, NoTrump)
+
+		assert.Error(err)
+		assert.Equal(ErrColorNotAllowed, err.Error())
+
+		err = game.PlaceBid("P1", Ninety, Color("moon"))
+
+		assert.Error(err)
+		assert.Equal(ErrColorNotAllowed, err.Error())
+	})
+
+	test.Run("should place a bid allowed by the rules", func(test *testing.T) {
+		game := newBiddingGame()
+		game.Rules = customRules
+
+		err := game.PlaceBid("P1", Ninety, AllTrump)
+
+		assert.NoError(err)
+		assert.Equal([]BidAction{{Sequence: 1, Kind: PlaceBidAction, Player: "P1", Value: Ninety, Color: AllTrump}}, game.Bids)
+	})
+}
+
+func TestCountingWithRules(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should count a WON game with CONTREE FFB rules", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Rules = ContreeFFBRules
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(99+20, game.Points["odd"])
+		assert.Equal(63, game.Points["even"])
+
+		assert.Equal(80+160+20, game.Scores["odd"])
+		assert.Equal(0, game.Scores["even"])
+	})
+
+	test.Run("should count a LOST game with CONTREE FFB rules", func(test *testing.T) {
+		game := newNormalGame()
+		game.Rules = ContreeFFBRules
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(0, game.Scores["odd"])
+		assert.Equal(160+80, game.Scores["even"])
+	})
+
+	test.Run("should count a CAPOT with CONTREE FFB rules", func(test *testing.T) {
+		game := newGameWithCapotWon()
+		game.Rules = ContreeFFBRules
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(0, game.Scores["odd"])
+		assert.Equal(250, game.Scores["even"])
+	})
+
+	test.Run("should count a WON game with REALIZED POINTS rules", func(test *testing.T) {
+		game := newGameWithBelote()
+		game.Rules = RealizedPointsRules
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(80+99+20, game.Scores["odd"])
+		assert.Equal(63, game.Scores["even"])
+	})
+
+	test.Run("should count a LOST game with REALIZED POINTS rules", func(test *testing.T) {
+		game := newNormalGame()
+		game.Rules = RealizedPointsRules
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(0, game.Scores["odd"])
+		assert.Equal(162+80, game.Scores["even"])
+	})
+
+	test.Run("should use the NO-TRUMP conversion of the rules", func(test *testing.T) {
+		game := newGameWithNoTrump()
+		game.Rules = ClassicRules
+		game.Rules.Name = "custom"
+		game.Rules.NoTrumpRatio = Ratio{1, 1}
+
+		game.calculatesTeamPointsAndScores()
+		assert.Equal(40, game.Points["odd"])
+		assert.Equal(122, game.Points["even"])
+	})
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+)
+
+const (
+	ErrAlreadyWatching = "ALREADY WATCHING"
+	ErrNotWatching     = "NOT WATCHING"
+)
+
+func (game Game) isSpectator(name string) bool {
+	for _, spectator := range game.Spectators {
+		if spectator == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSpectator lets anyone who is not seated watch the game, whatever its phase.
+func (game *Game) AddSpectator(name string) error {
+	if name == "" {
+		return errors.New(ErrEmptyPlayerName)
+	}
+
+	if _, ok := game.Players[name]; ok {
+		return errors.New(ErrAlreadyInGame)
+	}
+
+	if game.isSpectator(name) {
+		return errors.New(ErrAlreadyWatching)
+	}
+
+	game.Spectators = append(game.Spectators, name)
+	return nil
+}
+
+func (game *Game) RemoveSpectator(name string) error {
+	for i, spectator := range game.Spectators {
+		if spectator == name {
+			game.Spectators = append(game.Spectators[:i:i], game.Spectators[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(ErrNotWatching)
+}
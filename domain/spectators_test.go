@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpectators(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should add a spectator in any phase", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.AddSpectator("S1")
+
+		assert.NoError(err)
+		assert.Equal([]string{"S1"}, game.Spectators)
+	})
+
+	test.Run("should not add a seated player", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.AddSpectator("P1")
+
+		assert.Error(err)
+		assert.Equal(ErrAlreadyInGame, err.Error())
+	})
+
+	test.Run("should not add a spectator twice", func(test *testing.T) {
+		game := newPlayingGame()
+		_ = game.AddSpectator("S1")
+
+		err := game.AddSpectator("S1")
+
+		assert.Error(err)
+		assert.Equal(ErrAlreadyWatching, err.Error())
+	})
+
+	test.Run("should remove a spectator", func(test *testing.T) {
+		game := newPlayingGame()
+		_ = game.AddSpectator("S1")
+		_ = game.AddSpectator("S2")
+
+		err := game.RemoveSpectator("S1")
+
+		assert.NoError(err)
+		assert.Equal([]string{"S2"}, game.Spectators)
+	})
+
+	test.Run("should not remove an unknown spectator", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.RemoveSpectator("S1")
+
+		assert.Error(err)
+		assert.Equal(ErrNotWatching, err.Error())
+	})
+}
+
+func TestSpectatorView(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should hide every hand", func(test *testing.T) {
+		game := newPlayingGame()
+		_ = game.AddSpectator("S1")
+
+		got := game.SpectatorView(false)
+
+		for _, seat := range got.Players {
+			assert.Nil(seat.Hand)
+			assert.Equal(8, seat.CardsCount)
+		}
+		assert.Equal([]string{"S1"}, got.Spectators)
+		assert.Equal([]CardID{}, got.LegalCards)
+	})
+
+	test.Run("should show every hand to coaches", func(test *testing.T) {
+		game := newPlayingGame()
+
+		got := game.SpectatorView(true)
+
+		for name, seat := range got.Players {
+			assert.Equal(game.Players[name].Hand, seat.Hand)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+)
+
+const (
+	ErrDealNotFinished = "DEAL IS NOT FINISHED"
+)
+
+type StepKind string
+
+const (
+	DealStep       StepKind = "deal"
+	BidStep        StepKind = "bid"
+	PassStep       StepKind = "pass"
+	CoincheStep    StepKind = "coinche"
+	SurcoincheStep StepKind = "surcoinche"
+	PlayStep       StepKind = "play"
+	CountStep      StepKind = "count"
+)
+
+// TimelineStep holds the hands left after the step, so a client can jump to any step in both directions.
+type TimelineStep struct {
+	Kind        StepKind
+	Player      string   `json:",omitempty"`
+	Value       BidValue `json:",omitempty"`
+	Color       Color    `json:",omitempty"`
+	Card        CardID   `json:",omitempty"`
+	Trick       int
+	TrickWinner string `json:",omitempty"`
+	Hands       map[string][]CardID
+	Points      map[string]int `json:",omitempty"`
+	Scores      map[string]int `json:",omitempty"`
+}
+
+type Timeline struct {
+	GameID       int
+	Name         string
+	Root         int
+	Trump        Color
+	Teams        map[string]string
+	Declarations []Declaration
+	Steps        []TimelineStep
+}
+
+func copyHands(hands map[string][]CardID) map[string][]CardID {
+	copied := map[string][]CardID{}
+	for name, hand := range hands {
+		copied[name] = append([]CardID{}, hand...)
+	}
+	return copied
+}
+
+// getInitialHands rebuilds the hands from the played cards, the hands being empty once a deal is over.
+func (game Game) getInitialHands() map[string][]CardID {
+	hands := map[string][]CardID{}
+	for name := range game.Players {
+		hands[name] = []CardID{}
+	}
+
+	for _, turn := range game.Turns {
+		for _, play := range turn.Plays {
+			hands[play.PlayerName] = append(hands[play.PlayerName], play.Card)
+		}
+	}
+
+	return hands
+}
+
+var bidStepKinds = map[BidActionKind]StepKind{
+	PlaceBidAction:   BidStep,
+	PassAction:       PassStep,
+	CoincheAction:    CoincheStep,
+	SurcoincheAction: SurcoincheStep,
+}
+
+// getBidSteps follows the auction in the order of its actions, a coinche or a pass being about the last bid value.
+func (game Game) getBidSteps(hands map[string][]CardID) []TimelineStep {
+	steps := []TimelineStep{}
+
+	var value BidValue
+	for _, action := range game.Bids {
+		if action.Kind == PlaceBidAction {
+			value = action.Value
+		}
+
+		steps = append(steps, TimelineStep{
+			Kind:   bidStepKinds[action.Kind],
+			Player: action.Player,
+			Value:  value,
+			Color:  action.Color,
+			Hands:  copyHands(hands),
+		})
+	}
+
+	return steps
+}
+
+func (game Game) GetTimeline() (Timeline, error) {
+	if game.Phase != Counting && game.Phase != Finished {
+		return Timeline{}, errors.New(ErrDealNotFinished)
+	}
+
+	teams := map[string]string{}
+	for name, player := range game.Players {
+		teams[name] = player.Team
+	}
+
+	hands := game.getInitialHands()
+
+	steps := []TimelineStep{{Kind: DealStep, Hands: copyHands(hands)}}
+	steps = append(steps, game.getBidSteps(hands)...)
+
+	trump := game.trump()
+	for trick, turn := range game.Turns {
+		for index, play := range turn.Plays {
+			hands[play.PlayerName] = removeCard(append([]CardID{}, hands[play.PlayerName]...), play.Card)
+
+			step := TimelineStep{
+				Kind:   PlayStep,
+				Player: play.PlayerName,
+				Card:   play.Card,
+				Trick:  trick,
+				Hands:  copyHands(hands),
+			}
+			if index == len(turn.Plays)-1 {
+				step.TrickWinner = turn.GetWinner(trump)
+			}
+
+			steps = append(steps, step)
+		}
+	}
+
+	steps = append(steps, TimelineStep{
+		Kind:   CountStep,
+		Trick:  len(game.Turns),
+		Hands:  copyHands(hands),
+		Points: game.Points,
+		Scores: game.Scores,
+	})
+
+	return Timeline{
+		GameID:       game.ID,
+		Name:         game.Name,
+		Root:         game.Root,
+		Trump:        trump,
+		Teams:        teams,
+		Declarations: game.Declarations,
+		Steps:        steps,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeline(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should start with the dealt hands", func(test *testing.T) {
+		game := newNormalGame()
+		game.end()
+
+		got, err := game.GetTimeline()
+
+		assert.NoError(err)
+		assert.Equal(Heart, got.Trump)
+		assert.Equal("odd", got.Teams["P1"])
+		assert.Equal(35, len(got.Steps))
+		assert.Equal(DealStep, got.Steps[0].Kind)
+		assert.Equal([]CardID{C7, DJ, C8, DQ, HJ, C9, HQ, S8}, got.Steps[0].Hands["P1"])
+		assert.Equal(TimelineStep{Kind: BidStep, Player: "P1", Value: Eighty, Color: Heart, Hands: got.Steps[0].Hands}, got.Steps[1])
+	})
+
+	test.Run("should remove each played card from the hands", func(test *testing.T) {
+		game := newNormalGame()
+		game.end()
+
+		got, err := game.GetTimeline()
+
+		assert.NoError(err)
+		firstPlay := got.Steps[2]
+		assert.Equal(PlayStep, firstPlay.Kind)
+		assert.Equal("P1", firstPlay.Player)
+		assert.Equal(C7, firstPlay.Card)
+		assert.NotContains(firstPlay.Hands["P1"], C7)
+		assert.Equal(7, len(firstPlay.Hands["P1"]))
+		assert.Equal(8, len(firstPlay.Hands["P2"]))
+		assert.Equal(8, len(got.Steps[0].Hands["P1"]))
+	})
+
+	test.Run("should give the winner of each trick on its last card", func(test *testing.T) {
+		game := newNormalGame()
+		game.end()
+
+		got, err := game.GetTimeline()
+
+		assert.NoError(err)
+		winners := []string{}
+		for _, step := range got.Steps {
+			if step.TrickWinner != "" {
+				winners = append(winners, step.TrickWinner)
+			}
+		}
+		assert.Equal([]string{"P4", "P2", "P4", "P3", "P1", "P2", "P1", "P2"}, winners)
+		assert.Equal("", got.Steps[2].TrickWinner)
+		assert.Equal(1, got.Steps[6].Trick)
+	})
+
+	test.Run("should end with points and scores", func(test *testing.T) {
+		game := newNormalGame()
+		game.end()
+
+		got, err := game.GetTimeline()
+
+		assert.NoError(err)
+		last := got.Steps[len(got.Steps)-1]
+		assert.Equal(CountStep, last.Kind)
+		assert.Equal(game.Points, last.Points)
+		assert.Equal(game.Scores, last.Scores)
+		assert.Equal(0, len(last.Hands["P1"]))
+	})
+
+	test.Run("should follow the auction in order", func(test *testing.T) {
+		game := newNormalGame()
+		game.Bids = []BidAction{
+			{Sequence: 1, Kind: PassAction, Player: "P4"},
+			{Sequence: 2, Kind: PlaceBidAction, Player: "P1", Value: Eighty, Color: Spade},
+			{Sequence: 3, Kind: PlaceBidAction, Player: "P2", Value: Hundred, Color: Heart},
+			{Sequence: 4, Kind: CoincheAction, Player: "P3"},
+			{Sequence: 5, Kind: SurcoincheAction, Player: "P2"},
+		}
+		game.end()
+
+		got, err := game.GetTimeline()
+
+		assert.NoError(err)
+		assert.Equal(PassStep, got.Steps[1].Kind)
+		assert.Equal("P4", got.Steps[1].Player)
+		assert.Equal(Eighty, got.Steps[2].Value)
+		assert.Equal(Hundred, got.Steps[3].Value)
+		assert.Equal(CoincheStep, got.Steps[4].Kind)
+		assert.Equal("P3", got.Steps[4].Player)
+		assert.Equal(Hundred, got.Steps[4].Value)
+		assert.Equal(SurcoincheStep, got.Steps[5].Kind)
+		assert.Equal(PlayStep, got.Steps[6].Kind)
+	})
+
+	test.Run("should not give the timeline of a deal in progress", func(test *testing.T) {
+		game := newPlayingGame()
+
+		_, err := game.GetTimeline()
+
+		assert.Error(err)
+		assert.Equal(ErrDealNotFinished, err.Error())
+	})
+}
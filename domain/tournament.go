@@ -0,0 +1,558 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	ErrTournamentStarted   = "TOURNAMENT HAS STARTED"
+	ErrUnknownFormat       = "UNKNOWN TOURNAMENT FORMAT"
+	ErrInvalidPair         = "INVALID PAIR"
+	ErrPairExists          = "PAIR ALREADY EXISTS"
+	ErrPlayerInAnotherPair = "PLAYER IS IN ANOTHER PAIR"
+	ErrNotEnoughPairs      = "NOT ENOUGH PAIRS"
+	ErrRoundNotFinished    = "ROUND IS NOT FINISHED"
+	ErrNoMoreRounds        = "NO MORE ROUNDS"
+	ErrUnknownPair         = "UNKNOWN PAIR"
+)
+
+// MIN_PAIRS gives two tables, the least to compare the results of a board.
+const MIN_PAIRS = 4
+
+// MIN_MATCH_PAIRS is the least to play a match.
+const MIN_MATCH_PAIRS = 2
+
+// TournamentFormat tells how the pairs meet: on the same boards in duplicate, or in matches played to the target of
+// the tournament in a round robin or a knockout.
+type TournamentFormat string
+
+const (
+	DuplicateFormat  TournamentFormat = "duplicate"
+	RoundRobinFormat TournamentFormat = "round-robin"
+	KnockoutFormat   TournamentFormat = "knockout"
+)
+
+// Pair is a team of two players which keeps its name and its partners for the whole tournament.
+type Pair struct {
+	Name    string
+	Players []string
+}
+
+// Table seats a North-South pair against an East-West pair for one round, GameID being the board or the match they
+// play. The winner of a match is recorded once its game is finished, a pair without opponent in a knockout round
+// winning without game.
+type Table struct {
+	Round      int
+	Number     int
+	NorthSouth string
+	EastWest   string
+	GameID     int
+	Winner     string `json:",omitempty"`
+}
+
+// Tournament is played in duplicate by default: every table of a round is dealt the same board, derived from the
+// seed of the tournament, so the pairs are ranked on how they have played the same cards.
+type Tournament struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+	Format    TournamentFormat
+	Seed      int64
+	Rules     string
+	Target    int
+	Pairs     []Pair
+	Tables    []Table
+}
+
+// TournamentView is the tournament given to the clients, without the seed from which the boards of the rounds still to
+// be played could be dealt.
+type TournamentView struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+	Format    TournamentFormat
+	Rules     string
+	Target    int
+	Pairs     []Pair
+	Tables    []Table
+}
+
+// Standing sums the results of a pair on the boards or the matches it has finished. In duplicate, a board gives 2
+// match points for every other table of the round on which the pair has done better sitting in the same direction,
+// and 1 for every tie. Otherwise, a won match gives 2 match points.
+type Standing struct {
+	Pair        string
+	MatchPoints int
+	Score       int
+	Points      int
+	Boards      int
+}
+
+func (tournament Tournament) View() TournamentView {
+	return TournamentView{
+		ID:        tournament.ID,
+		Name:      tournament.Name,
+		CreatedAt: tournament.CreatedAt,
+		Format:    tournament.getFormat(),
+		Rules:     tournament.Rules,
+		Target:    tournament.Target,
+		Pairs:     tournament.Pairs,
+		Tables:    tournament.Tables,
+	}
+}
+
+// NewTournament plays the matches to the default target when none is given.
+func NewTournament(name string, format TournamentFormat, rules string, target int, seed int64) (Tournament, error) {
+	if format == "" {
+		format = DuplicateFormat
+	}
+
+	if format != DuplicateFormat && format != RoundRobinFormat && format != KnockoutFormat {
+		return Tournament{}, errors.New(ErrUnknownFormat)
+	}
+
+	_, err := GetRules(rules)
+	if err != nil {
+		return Tournament{}, err
+	}
+
+	if target == 0 {
+		target = DEFAULT_TARGET
+	}
+
+	err = checkTarget(target)
+	if err != nil {
+		return Tournament{}, err
+	}
+
+	return Tournament{
+		Name:   name,
+		Format: format,
+		Seed:   seed,
+		Rules:  rules,
+		Target: target,
+		Pairs:  []Pair{},
+		Tables: []Table{},
+	}, nil
+}
+
+// getFormat falls back on duplicate for the tournaments created before the formats.
+func (tournament Tournament) getFormat() TournamentFormat {
+	if tournament.Format == "" {
+		return DuplicateFormat
+	}
+	return tournament.Format
+}
+
+func (tournament Tournament) IsDuplicate() bool {
+	return tournament.getFormat() == DuplicateFormat
+}
+
+func (tournament Tournament) GetPair(name string) (Pair, bool) {
+	for _, pair := range tournament.Pairs {
+		if pair.Name == name {
+			return pair, true
+		}
+	}
+	return Pair{}, false
+}
+
+func (tournament Tournament) isInPair(player string) bool {
+	for _, pair := range tournament.Pairs {
+		for _, partner := range pair.Players {
+			if partner == player {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddPair registers a pair until the first round has started.
+func (tournament *Tournament) AddPair(name string, players []string) error {
+	if len(tournament.Tables) > 0 {
+		return errors.New(ErrTournamentStarted)
+	}
+
+	if name == "" || len(players) != 2 || players[0] == "" || players[1] == "" || players[0] == players[1] {
+		return errors.New(ErrInvalidPair)
+	}
+
+	if _, ok := tournament.GetPair(name); ok {
+		return errors.New(ErrPairExists)
+	}
+
+	for _, player := range players {
+		if tournament.isInPair(player) {
+			return errors.New(ErrPlayerInAnotherPair)
+		}
+	}
+
+	tournament.Pairs = append(tournament.Pairs, Pair{Name: name, Players: []string{players[0], players[1]}})
+	return nil
+}
+
+// Rounds gives the number of rounds needed for every East-West pair to meet every North-South pair in duplicate, for
+// every pair to meet every other one in a round robin, or to keep a single pair in a knockout.
+func (tournament Tournament) Rounds() int {
+	pairsCount := len(tournament.Pairs)
+
+	switch tournament.getFormat() {
+	case RoundRobinFormat:
+		if pairsCount%2 != 0 {
+			return pairsCount
+		}
+		return pairsCount - 1
+	case KnockoutFormat:
+		rounds := 0
+		for remaining := pairsCount; remaining > 1; remaining = (remaining + 1) / 2 {
+			rounds++
+		}
+		return rounds
+	}
+
+	return pairsCount / 2
+}
+
+func (tournament Tournament) CurrentRound() int {
+	round := 0
+	for _, table := range tournament.Tables {
+		if table.Round > round {
+			round = table.Round
+		}
+	}
+	return round
+}
+
+func (table Table) isBye() bool {
+	return table.EastWest == ""
+}
+
+// RecordWinners gives the winners of the finished games to their tables.
+func (tournament *Tournament) RecordWinners(games map[int]Game) {
+	for i, table := range tournament.Tables {
+		game, ok := games[table.GameID]
+		if table.isBye() || !ok || !game.IsFinished() {
+			continue
+		}
+		tournament.Tables[i].Winner = game.Winner
+	}
+}
+
+func (tournament Tournament) isRoundFinished(games map[int]Game) bool {
+	for _, table := range tournament.Tables {
+		if !table.isBye() && !games[table.GameID].IsFinished() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsRoundOfGameFinished tells whether every table of the round of the game is finished, so its board can be shown
+// without helping the tables still playing it.
+func (tournament Tournament) IsRoundOfGameFinished(gameID int, games map[int]Game) bool {
+	round := 0
+	for _, table := range tournament.Tables {
+		if table.GameID == gameID {
+			round = table.Round
+		}
+	}
+
+	for _, table := range tournament.Tables {
+		if table.Round == round && !table.isBye() && !games[table.GameID].IsFinished() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFinished tells whether the last round has been played.
+func (tournament Tournament) IsFinished(games map[int]Game) bool {
+	return len(tournament.Tables) > 0 && tournament.CurrentRound() >= tournament.Rounds() && tournament.isRoundFinished(games)
+}
+
+func (tournament Tournament) checkPairsCount() error {
+	pairsCount := len(tournament.Pairs)
+
+	if tournament.IsDuplicate() {
+		if pairsCount < MIN_PAIRS || pairsCount%2 != 0 {
+			return errors.New(ErrNotEnoughPairs)
+		}
+		return nil
+	}
+
+	if pairsCount < MIN_MATCH_PAIRS {
+		return errors.New(ErrNotEnoughPairs)
+	}
+	return nil
+}
+
+// NextRound seats the pairs of the next round, once the games of the previous one are finished.
+func (tournament *Tournament) NextRound(games map[int]Game) (int, error) {
+	err := tournament.checkPairsCount()
+	if err != nil {
+		return 0, err
+	}
+
+	if !tournament.isRoundFinished(games) {
+		return 0, errors.New(ErrRoundNotFinished)
+	}
+	tournament.RecordWinners(games)
+
+	round := tournament.CurrentRound() + 1
+	if round > tournament.Rounds() {
+		return 0, errors.New(ErrNoMoreRounds)
+	}
+
+	var opponents [][2]string
+	switch tournament.getFormat() {
+	case RoundRobinFormat:
+		opponents = tournament.getRoundRobinOpponents(round)
+	case KnockoutFormat:
+		opponents = tournament.getKnockoutOpponents(round)
+	default:
+		opponents = tournament.getMitchellOpponents(round)
+	}
+
+	for i, pairs := range opponents {
+		table := Table{Round: round, Number: i + 1, NorthSouth: pairs[0], EastWest: pairs[1]}
+		if table.isBye() {
+			table.Winner = table.NorthSouth
+		}
+		tournament.Tables = append(tournament.Tables, table)
+	}
+
+	return round, nil
+}
+
+// getMitchellOpponents keeps the first half of the pairs North-South at their table while the East-West pairs move to
+// the next table at each round.
+func (tournament Tournament) getMitchellOpponents(round int) [][2]string {
+	opponents := [][2]string{}
+	tablesCount := len(tournament.Pairs) / 2
+	for i := 0; i < tablesCount; i++ {
+		opponents = append(opponents, [2]string{
+			tournament.Pairs[i].Name,
+			tournament.Pairs[tablesCount+(i+round-1)%tablesCount].Name,
+		})
+	}
+	return opponents
+}
+
+// getRoundRobinOpponents turns the pairs around the first one, which stays in place, so every pair meets every other
+// one once. With an odd number of pairs, the one facing the empty place does not play this round.
+func (tournament Tournament) getRoundRobinOpponents(round int) [][2]string {
+	names := []string{}
+	for _, pair := range tournament.Pairs {
+		names = append(names, pair.Name)
+	}
+	if len(names)%2 != 0 {
+		names = append(names, "")
+	}
+
+	count := len(names)
+	turned := []string{names[0]}
+	for i := 0; i < count-1; i++ {
+		turned = append(turned, names[1+(i+round-1)%(count-1)])
+	}
+
+	opponents := [][2]string{}
+	for i := 0; i < count/2; i++ {
+		first, second := turned[i], turned[count-1-i]
+		if first == "" || second == "" {
+			continue
+		}
+		opponents = append(opponents, [2]string{first, second})
+	}
+	return opponents
+}
+
+// getKnockoutOpponents pairs the winners of the previous round in the order of their tables, the last one going
+// through the round without opponent when they are odd.
+func (tournament Tournament) getKnockoutOpponents(round int) [][2]string {
+	remaining := []string{}
+	if round == 1 {
+		for _, pair := range tournament.Pairs {
+			remaining = append(remaining, pair.Name)
+		}
+	} else {
+		for _, table := range tournament.Tables {
+			if table.Round == round-1 {
+				remaining = append(remaining, table.Winner)
+			}
+		}
+	}
+
+	opponents := [][2]string{}
+	for i := 0; i < len(remaining); i += 2 {
+		if i+1 < len(remaining) {
+			opponents = append(opponents, [2]string{remaining[i], remaining[i+1]})
+		} else {
+			opponents = append(opponents, [2]string{remaining[i], ""})
+		}
+	}
+	return opponents
+}
+
+// GetBracket groups the tables by round.
+func (tournament Tournament) GetBracket() [][]Table {
+	bracket := [][]Table{}
+	for _, table := range tournament.Tables {
+		for len(bracket) < table.Round {
+			bracket = append(bracket, []Table{})
+		}
+		bracket[table.Round-1] = append(bracket[table.Round-1], table)
+	}
+	return bracket
+}
+
+// GetMatchName names the game of a table.
+func (tournament Tournament) GetMatchName(table Table) string {
+	return fmt.Sprintf("%s - round %d - table %d", tournament.Name, table.Round, table.Number)
+}
+
+// Seat is taken by a player of a pair around the table of a board, from North to West.
+type Seat struct {
+	Player string
+	Team   string
+}
+
+// NewBoard deals the board of the round to the table. The tables of a round share the same seed, so they are dealt
+// the same cards from the same seats, East-West dealing so that North is the first to bid. The board is built from
+// the events which create and deal it, so it can be replayed like any other game.
+func (tournament Tournament) NewBoard(table Table) (Game, []Event, error) {
+	northSouth, ok := tournament.GetPair(table.NorthSouth)
+	if !ok {
+		return Game{}, nil, errors.New(ErrUnknownPair)
+	}
+
+	eastWest, ok := tournament.GetPair(table.EastWest)
+	if !ok {
+		return Game{}, nil, errors.New(ErrUnknownPair)
+	}
+
+	seats := []Seat{
+		{northSouth.Players[0], northSouth.Name},
+		{eastWest.Players[0], eastWest.Name},
+		{northSouth.Players[1], northSouth.Name},
+		{eastWest.Players[1], eastWest.Name},
+	}
+	events := []Event{
+		NewEvent(CreateEvent, "", EventPayload{
+			Name:  tournament.GetMatchName(table),
+			Rules: tournament.Rules,
+			Seed:  mixSeed(tournament.Seed, table.Round),
+		}),
+		NewEvent(DealBoardEvent, "", EventPayload{Seats: seats, Dealer: eastWest.Players[1]}),
+	}
+
+	game, err := Replay(events)
+	if err != nil {
+		return Game{}, nil, err
+	}
+	events[1].Payload.Hands = game.Hands()
+
+	return game, events, nil
+}
+
+// dealBoard seats the players in the given order and deals the single deal of the board.
+func (game *Game) dealBoard(seats []Seat, dealer string) error {
+	if game.Phase != Teaming {
+		return errors.New(ErrNotTeaming)
+	}
+
+	game.Rules.SingleDeal = true
+	for i, seat := range seats {
+		game.Players[seat.Player] = Player{Team: seat.Team, Order: i + 1, InitialOrder: i + 1}
+	}
+	game.Dealer = dealer
+	game.deal()
+
+	return nil
+}
+
+type boardResult struct {
+	table      Table
+	difference int
+}
+
+// GetStandings ranks the pairs on the finished boards or matches, by match points then by total score and card
+// points.
+func (tournament Tournament) GetStandings(games map[int]Game) []Standing {
+	standings := map[string]*Standing{}
+	for _, pair := range tournament.Pairs {
+		standings[pair.Name] = &Standing{Pair: pair.Name}
+	}
+
+	rounds := map[int][]boardResult{}
+	for _, table := range tournament.Tables {
+		if table.isBye() {
+			standings[table.NorthSouth].MatchPoints += 2
+			continue
+		}
+
+		game, ok := games[table.GameID]
+		if !ok || !game.IsFinished() {
+			continue
+		}
+
+		for _, pair := range []string{table.NorthSouth, table.EastWest} {
+			standing := standings[pair]
+			standing.Score += game.Scores[pair]
+			standing.Points += game.Points[pair]
+			standing.Boards++
+		}
+
+		if !tournament.IsDuplicate() {
+			if winner, ok := standings[game.Winner]; ok {
+				winner.MatchPoints += 2
+			}
+			continue
+		}
+
+		difference := game.Scores[table.NorthSouth] - game.Scores[table.EastWest]
+		rounds[table.Round] = append(rounds[table.Round], boardResult{table: table, difference: difference})
+	}
+
+	for _, results := range rounds {
+		for _, result := range results {
+			matchPoints := 0
+			for _, other := range results {
+				if other.table.Number == result.table.Number {
+					continue
+				}
+				if result.difference > other.difference {
+					matchPoints += 2
+				} else if result.difference == other.difference {
+					matchPoints++
+				}
+			}
+
+			standings[result.table.NorthSouth].MatchPoints += matchPoints
+			standings[result.table.EastWest].MatchPoints += 2*(len(results)-1) - matchPoints
+		}
+	}
+
+	sorted := make([]Standing, 0, len(standings))
+	for _, standing := range standings {
+		sorted = append(sorted, *standing)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].MatchPoints != sorted[j].MatchPoints {
+			return sorted[i].MatchPoints > sorted[j].MatchPoints
+		}
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score > sorted[j].Score
+		}
+		if sorted[i].Points != sorted[j].Points {
+			return sorted[i].Points > sorted[j].Points
+		}
+		return sorted[i].Pair < sorted[j].Pair
+	})
+
+	return sorted
+}
@@ -0,0 +1,507 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTournamentWithPairs(test *testing.T) Tournament {
+	tournament, err := NewTournament("CUP", "", "", 0, 42)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	pairs := map[string][]string{
+		"A": {"A1", "A2"},
+		"B": {"B1", "B2"},
+		"C": {"C1", "C2"},
+		"D": {"D1", "D2"},
+	}
+	for _, name := range []string{"A", "B", "C", "D"} {
+		err = tournament.AddPair(name, pairs[name])
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	return tournament
+}
+
+func newFinishedBoard(scores map[string]int) Game {
+	return Game{Phase: Finished, Scores: scores, Points: map[string]int{}}
+}
+
+func TestTournamentPairs(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should fail with unknown rules", func(test *testing.T) {
+		_, err := NewTournament("CUP", "", "belote", 0, 42)
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownRules, err.Error())
+	})
+
+	test.Run("should register a pair", func(test *testing.T) {
+		tournament, _ := NewTournament("CUP", "", "", 0, 42)
+
+		err := tournament.AddPair("A", []string{"A1", "A2"})
+
+		assert.NoError(err)
+		assert.Equal([]Pair{{Name: "A", Players: []string{"A1", "A2"}}}, tournament.Pairs)
+	})
+
+	test.Run("should fail with an invalid pair", func(test *testing.T) {
+		tournament, _ := NewTournament("CUP", "", "", 0, 42)
+
+		for _, players := range [][]string{{"A1"}, {"A1", "A1"}, {"A1", ""}, {"A1", "A2", "A3"}} {
+			err := tournament.AddPair("A", players)
+
+			assert.Error(err)
+			assert.Equal(ErrInvalidPair, err.Error())
+		}
+		assert.Equal(0, len(tournament.Pairs))
+	})
+
+	test.Run("should fail when the pair already exists", func(test *testing.T) {
+		tournament, _ := NewTournament("CUP", "", "", 0, 42)
+		_ = tournament.AddPair("A", []string{"A1", "A2"})
+
+		err := tournament.AddPair("A", []string{"B1", "B2"})
+
+		assert.Error(err)
+		assert.Equal(ErrPairExists, err.Error())
+	})
+
+	test.Run("should fail when a player is in another pair", func(test *testing.T) {
+		tournament, _ := NewTournament("CUP", "", "", 0, 42)
+		_ = tournament.AddPair("A", []string{"A1", "A2"})
+
+		err := tournament.AddPair("B", []string{"B1", "A2"})
+
+		assert.Error(err)
+		assert.Equal(ErrPlayerInAnotherPair, err.Error())
+	})
+
+	test.Run("should fail to register a pair once started", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+
+		err := tournament.AddPair("E", []string{"E1", "E2"})
+
+		assert.Error(err)
+		assert.Equal(ErrTournamentStarted, err.Error())
+	})
+}
+
+func TestTournamentRounds(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should fail without enough pairs", func(test *testing.T) {
+		tournament, _ := NewTournament("CUP", "", "", 0, 42)
+		_ = tournament.AddPair("A", []string{"A1", "A2"})
+		_ = tournament.AddPair("B", []string{"B1", "B2"})
+
+		_, err := tournament.NextRound(map[int]Game{})
+
+		assert.Error(err)
+		assert.Equal(ErrNotEnoughPairs, err.Error())
+	})
+
+	test.Run("should move the East-West pairs at each round", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+
+		round, err := tournament.NextRound(map[int]Game{})
+		assert.NoError(err)
+		assert.Equal(1, round)
+
+		tournament.Tables[0].GameID = 1
+		tournament.Tables[1].GameID = 2
+		games := map[int]Game{1: {Phase: Finished}, 2: {Phase: Finished}}
+
+		round, err = tournament.NextRound(games)
+		assert.NoError(err)
+		assert.Equal(2, round)
+
+		assert.Equal([]Table{
+			{Round: 1, Number: 1, NorthSouth: "A", EastWest: "C", GameID: 1},
+			{Round: 1, Number: 2, NorthSouth: "B", EastWest: "D", GameID: 2},
+			{Round: 2, Number: 1, NorthSouth: "A", EastWest: "D"},
+			{Round: 2, Number: 2, NorthSouth: "B", EastWest: "C"},
+		}, tournament.Tables)
+	})
+
+	test.Run("should fail when the games of the round are not finished", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+
+		_, err := tournament.NextRound(map[int]Game{0: {Phase: Playing}})
+
+		assert.Error(err)
+		assert.Equal(ErrRoundNotFinished, err.Error())
+		assert.Equal(1, tournament.CurrentRound())
+	})
+
+	test.Run("should tell when every table of the round of a game is finished", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+		tournament.Tables[0].GameID = 1
+		tournament.Tables[1].GameID = 2
+
+		assert.False(tournament.IsRoundOfGameFinished(1, map[int]Game{1: {Phase: Finished}, 2: {Phase: Playing}}))
+		assert.True(tournament.IsRoundOfGameFinished(1, map[int]Game{1: {Phase: Finished}, 2: {Phase: Finished}}))
+	})
+
+	test.Run("should fail once every pair has met", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		games := map[int]Game{0: {Phase: Finished}}
+		_, _ = tournament.NextRound(games)
+		_, _ = tournament.NextRound(games)
+
+		_, err := tournament.NextRound(games)
+
+		assert.Error(err)
+		assert.Equal(ErrNoMoreRounds, err.Error())
+	})
+}
+
+func TestTournamentBoards(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should deal the same cards to every table of a round", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+
+		first, _, err := tournament.NewBoard(tournament.Tables[0])
+		assert.NoError(err)
+		second, _, err := tournament.NewBoard(tournament.Tables[1])
+		assert.NoError(err)
+
+		assert.Equal(Bidding, first.Phase)
+		assert.Equal(first.Players["A1"].Hand, second.Players["B1"].Hand)
+		assert.Equal(first.Players["C1"].Hand, second.Players["D1"].Hand)
+		assert.Equal(first.Players["A2"].Hand, second.Players["B2"].Hand)
+		assert.Equal(first.Players["C2"].Hand, second.Players["D2"].Hand)
+		assert.Equal(8, len(first.Players["A1"].Hand))
+	})
+
+	test.Run("should seat the pairs around the table", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+
+		game, _, _ := tournament.NewBoard(tournament.Tables[0])
+
+		assert.Equal(Player{Team: "A", Order: 1, InitialOrder: 1, Hand: game.Players["A1"].Hand}, game.Players["A1"])
+		assert.Equal(2, game.Players["C1"].InitialOrder)
+		assert.Equal(3, game.Players["A2"].InitialOrder)
+		assert.Equal(4, game.Players["C2"].InitialOrder)
+		assert.Equal("C2", game.Dealer)
+		assert.Equal("A1", game.PlayerToAct())
+		assert.True(game.Rules.SingleDeal)
+	})
+
+	test.Run("should replay the board from its events", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+
+		game, events, err := tournament.NewBoard(tournament.Tables[0])
+		assert.NoError(err)
+
+		replayed, err := Replay(events)
+
+		assert.NoError(err)
+		assert.Equal(game.Players, replayed.Players)
+		assert.Equal("C2", replayed.Dealer)
+		assert.Equal(events[1].Payload.Hands, replayed.Hands())
+		assert.True(replayed.Rules.SingleDeal)
+	})
+
+	test.Run("should deal another board at the next round", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		games := map[int]Game{0: {Phase: Finished}}
+		_, _ = tournament.NextRound(games)
+		_, _ = tournament.NextRound(games)
+
+		first, _, _ := tournament.NewBoard(tournament.Tables[0])
+		second, _, _ := tournament.NewBoard(tournament.Tables[2])
+
+		assert.NotEqual(first.Players["A1"].Hand, second.Players["A1"].Hand)
+	})
+
+	test.Run("should finish the board when every player passes", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+		game, _, _ := tournament.NewBoard(tournament.Tables[0])
+
+		for _, player := range []string{"A1", "C1", "A2", "C2"} {
+			err := game.Pass(player)
+			assert.NoError(err)
+		}
+
+		assert.Equal(Finished, game.Phase)
+		assert.Equal(map[string]int{"A": 0, "C": 0}, game.Scores)
+		assert.Equal("", game.Winner)
+	})
+}
+
+func TestTournamentStandings(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should compare the tables which have played the same board", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+		tournament.Tables[0].GameID = 1
+		tournament.Tables[1].GameID = 2
+
+		got := tournament.GetStandings(map[int]Game{
+			1: newFinishedBoard(map[string]int{"A": 160, "C": 0}),
+			2: newFinishedBoard(map[string]int{"B": 0, "D": 250}),
+		})
+
+		assert.Equal([]Standing{
+			{Pair: "D", MatchPoints: 2, Score: 250, Boards: 1},
+			{Pair: "A", MatchPoints: 2, Score: 160, Boards: 1},
+			{Pair: "B", MatchPoints: 0, Score: 0, Boards: 1},
+			{Pair: "C", MatchPoints: 0, Score: 0, Boards: 1},
+		}, got)
+	})
+
+	test.Run("should share the match points of a tie", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+		tournament.Tables[0].GameID = 1
+		tournament.Tables[1].GameID = 2
+
+		got := tournament.GetStandings(map[int]Game{
+			1: newFinishedBoard(map[string]int{"A": 0, "C": 160}),
+			2: newFinishedBoard(map[string]int{"B": 0, "D": 160}),
+		})
+
+		for _, standing := range got {
+			assert.Equal(1, standing.MatchPoints)
+		}
+	})
+
+	test.Run("should ignore the boards which are not finished", func(test *testing.T) {
+		tournament := newTournamentWithPairs(test)
+		_, _ = tournament.NextRound(map[int]Game{})
+		tournament.Tables[0].GameID = 1
+		tournament.Tables[1].GameID = 2
+
+		got := tournament.GetStandings(map[int]Game{
+			1: newFinishedBoard(map[string]int{"A": 160, "C": 0}),
+			2: {Phase: Playing, Scores: map[string]int{"B": 500}},
+		})
+
+		assert.Equal(Standing{Pair: "A", MatchPoints: 0, Score: 160, Boards: 1}, got[0])
+		assert.Equal(Standing{Pair: "B"}, got[1])
+	})
+}
+
+func newMatchTournament(test *testing.T, format TournamentFormat, pairs ...string) Tournament {
+	tournament, err := NewTournament("CUP", format, "", 0, 42)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	for _, name := range pairs {
+		err = tournament.AddPair(name, []string{name + "1", name + "2"})
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	return tournament
+}
+
+// finishRound gives a finished game to every table of the round, won by the pair chosen by the winner function.
+func finishRound(tournament *Tournament, games map[int]Game, round int, winner func(Table) string) {
+	for i, table := range tournament.Tables {
+		if table.Round != round || table.isBye() {
+			continue
+		}
+		gameID := len(games) + 1
+		tournament.Tables[i].GameID = gameID
+		games[gameID] = Game{
+			Phase:  Finished,
+			Winner: winner(table),
+			Scores: map[string]int{table.NorthSouth: 1000, table.EastWest: 500},
+			Points: map[string]int{},
+		}
+	}
+}
+
+func northSouthWins(table Table) string {
+	return table.NorthSouth
+}
+
+func TestTournamentFormats(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should be played in duplicate by default", func(test *testing.T) {
+		tournament, err := NewTournament("CUP", "", "", 0, 42)
+
+		assert.NoError(err)
+		assert.Equal(DuplicateFormat, tournament.Format)
+		assert.Equal(DEFAULT_TARGET, tournament.Target)
+	})
+
+	test.Run("should leave the seed out of its view", func(test *testing.T) {
+		tournament, _ := NewTournament("CUP", KnockoutFormat, "", 0, 42)
+
+		got, err := json.Marshal(tournament.View())
+
+		assert.NoError(err)
+		assert.NotContains(string(got), "Seed")
+		assert.Contains(string(got), string(KnockoutFormat))
+	})
+
+	test.Run("should fail with an unknown format", func(test *testing.T) {
+		_, err := NewTournament("CUP", "swiss", "", 0, 42)
+
+		assert.Error(err)
+		assert.Equal(ErrUnknownFormat, err.Error())
+	})
+
+	test.Run("should fail with an invalid target", func(test *testing.T) {
+		_, err := NewTournament("CUP", KnockoutFormat, "", 1200, 42)
+
+		assert.Error(err)
+		assert.Equal(ErrInvalidTarget, err.Error())
+	})
+
+	test.Run("should fail to play a match without two pairs", func(test *testing.T) {
+		tournament := newMatchTournament(test, RoundRobinFormat, "A")
+
+		_, err := tournament.NextRound(map[int]Game{})
+
+		assert.Error(err)
+		assert.Equal(ErrNotEnoughPairs, err.Error())
+	})
+}
+
+func TestRoundRobin(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should let every pair meet every other one once", func(test *testing.T) {
+		tournament := newMatchTournament(test, RoundRobinFormat, "A", "B", "C", "D")
+		games := map[int]Game{}
+
+		for round := 1; round <= 3; round++ {
+			_, err := tournament.NextRound(games)
+			assert.NoError(err)
+			finishRound(&tournament, games, round, northSouthWins)
+		}
+
+		_, err := tournament.NextRound(games)
+		assert.Error(err)
+		assert.Equal(ErrNoMoreRounds, err.Error())
+
+		met := map[string]int{}
+		for _, table := range tournament.Tables {
+			met[table.NorthSouth+table.EastWest]++
+			met[table.EastWest+table.NorthSouth]++
+		}
+		for _, first := range []string{"A", "B", "C", "D"} {
+			for _, second := range []string{"A", "B", "C", "D"} {
+				if first != second {
+					assert.Equal(1, met[first+second], first+second)
+				}
+			}
+		}
+		assert.Equal(6, len(tournament.Tables))
+		assert.True(tournament.IsFinished(games))
+	})
+
+	test.Run("should let a pair rest at each round when they are odd", func(test *testing.T) {
+		tournament := newMatchTournament(test, RoundRobinFormat, "A", "B", "C")
+		games := map[int]Game{}
+
+		for round := 1; round <= 3; round++ {
+			_, err := tournament.NextRound(games)
+			assert.NoError(err)
+			finishRound(&tournament, games, round, northSouthWins)
+		}
+
+		assert.Equal(3, len(tournament.Tables))
+		for _, table := range tournament.Tables {
+			assert.False(table.isBye())
+		}
+	})
+
+	test.Run("should rank the pairs on the matches they have won", func(test *testing.T) {
+		tournament := newMatchTournament(test, RoundRobinFormat, "A", "B", "C")
+		games := map[int]Game{}
+		_, _ = tournament.NextRound(games)
+		finishRound(&tournament, games, 1, func(table Table) string { return table.EastWest })
+
+		got := tournament.GetStandings(games)
+
+		winner := tournament.Tables[0].EastWest
+		assert.Equal(Standing{Pair: winner, MatchPoints: 2, Score: 500, Boards: 1}, got[0])
+		assert.Equal(0, got[1].MatchPoints)
+		assert.Equal(0, got[2].MatchPoints)
+	})
+}
+
+func TestKnockout(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should advance the winners until a single pair remains", func(test *testing.T) {
+		tournament := newMatchTournament(test, KnockoutFormat, "A", "B", "C", "D")
+		games := map[int]Game{}
+
+		_, err := tournament.NextRound(games)
+		assert.NoError(err)
+		finishRound(&tournament, games, 1, func(table Table) string { return table.EastWest })
+
+		_, err = tournament.NextRound(games)
+		assert.NoError(err)
+		finishRound(&tournament, games, 2, northSouthWins)
+
+		_, err = tournament.NextRound(games)
+		assert.Error(err)
+		assert.Equal(ErrNoMoreRounds, err.Error())
+
+		assert.Equal([][]Table{
+			{
+				{Round: 1, Number: 1, NorthSouth: "A", EastWest: "B", GameID: 1, Winner: "B"},
+				{Round: 1, Number: 2, NorthSouth: "C", EastWest: "D", GameID: 2, Winner: "D"},
+			},
+			{
+				{Round: 2, Number: 1, NorthSouth: "B", EastWest: "D", GameID: 3, Winner: "B"},
+			},
+		}, tournament.GetBracket())
+		assert.True(tournament.IsFinished(games))
+		assert.Equal("B", tournament.GetStandings(games)[0].Pair)
+	})
+
+	test.Run("should let a pair through a round without opponent", func(test *testing.T) {
+		tournament := newMatchTournament(test, KnockoutFormat, "A", "B", "C")
+		games := map[int]Game{}
+
+		_, _ = tournament.NextRound(games)
+
+		assert.Equal(Table{Round: 1, Number: 2, NorthSouth: "C", Winner: "C"}, tournament.Tables[1])
+
+		finishRound(&tournament, games, 1, northSouthWins)
+		_, err := tournament.NextRound(games)
+
+		assert.NoError(err)
+		assert.Equal(Table{Round: 2, Number: 1, NorthSouth: "A", EastWest: "C"}, tournament.Tables[2])
+		assert.Equal(2, tournament.Rounds())
+	})
+
+	test.Run("should not advance before the matches are finished", func(test *testing.T) {
+		tournament := newMatchTournament(test, KnockoutFormat, "A", "B")
+		_, _ = tournament.NextRound(map[int]Game{})
+		tournament.Tables[0].GameID = 1
+
+		_, err := tournament.NextRound(map[int]Game{1: {Phase: Counting}})
+
+		assert.Error(err)
+		assert.Equal(ErrRoundNotFinished, err.Error())
+		assert.False(tournament.IsFinished(map[int]Game{1: {Phase: Counting}}))
+	})
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ErrPasswordTooShort   = "PASSWORD TOO SHORT"
+	ErrUserAlreadyExists  = "USER ALREADY EXISTS"
+	ErrUserNotFound       = "USER NOT FOUND"
+	ErrInvalidCredentials = "INVALID CREDENTIALS"
+	ErrReservedName       = "RESERVED PLAYER NAME"
+)
+
+const MIN_PASSWORD_LENGTH = 8
+
+// User is an account, its name being the player name used in every game it joins.
+type User struct {
+	ID           int
+	Name         string
+	PasswordHash string `json:"-"`
+	CreatedAt    time.Time
+}
+
+func CheckCredentials(name string, password string) error {
+	if name == "" {
+		return errors.New(ErrEmptyPlayerName)
+	}
+
+	if IsBotName(name) {
+		return errors.New(ErrReservedName)
+	}
+
+	if len(password) < MIN_PASSWORD_LENGTH {
+		return errors.New(ErrPasswordTooShort)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCredentials(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should accept a name and a long enough password", func(test *testing.T) {
+		assert.NoError(CheckCredentials("P1", "password"))
+	})
+
+	test.Run("should refuse an empty name", func(test *testing.T) {
+		err := CheckCredentials("", "password")
+
+		assert.Error(err)
+		assert.Equal(ErrEmptyPlayerName, err.Error())
+	})
+
+	test.Run("should refuse the name of a bot", func(test *testing.T) {
+		err := CheckCredentials("Bot 1", "password")
+
+		assert.Error(err)
+		assert.Equal(ErrReservedName, err.Error())
+	})
+
+	test.Run("should refuse a short password", func(test *testing.T) {
+		err := CheckCredentials("P1", "short")
+
+		assert.Error(err)
+		assert.Equal(ErrPasswordTooShort, err.Error())
+	})
+}
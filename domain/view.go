@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+)
+
+type SeatView struct {
+	Team         string
+	Order        int
+	InitialOrder int
+	Hand         []CardID
+	CardsCount   int
+	Bot          string
+}
+
+type PlayerView struct {
+	ID           int
+	Name         string
+	CreatedAt    time.Time
+	Players      map[string]SeatView
+	Phase        Phase
+	Bids         []BidAction
+	Turns        []Turn
+	Scores       map[string]int
+	Points       map[string]int
+	Root         int
+	Redeals      int
+	Target       int
+	Winner       string
+	Declarations []Declaration
+	Rules        Rules
+	Trump        Color
+	LegalCards   []CardID
+	LegalBids    LegalBids
+	Spectators   []string
+	Dealer       string
+}
+
+func (player Player) seatFor(isRecipient bool) SeatView {
+	seat := SeatView{
+		Team:         player.Team,
+		Order:        player.Order,
+		InitialOrder: player.InitialOrder,
+		CardsCount:   len(player.Hand),
+		Bot:          player.Bot,
+	}
+
+	if isRecipient {
+		seat.Hand = player.Hand
+	}
+
+	return seat
+}
+
+// ViewFor only keeps the hand of the given player, the other hands are replaced by their size and the deck is hidden.
+// An unknown or empty player name gives a view without any hand nor legal move.
+func (game Game) ViewFor(playerName string) PlayerView {
+	players := map[string]SeatView{}
+	for name, player := range game.Players {
+		players[name] = player.seatFor(name == playerName)
+	}
+
+	return game.view(players, playerName)
+}
+
+// SpectatorView hides every hand, unless showHands is set for the delayed views given to coaches.
+func (game Game) SpectatorView(showHands bool) PlayerView {
+	players := map[string]SeatView{}
+	for name, player := range game.Players {
+		players[name] = player.seatFor(showHands)
+	}
+
+	return game.view(players, "")
+}
+
+func (game Game) view(players map[string]SeatView, playerName string) PlayerView {
+	return PlayerView{
+		ID:           game.ID,
+		Name:         game.Name,
+		CreatedAt:    game.CreatedAt,
+		Players:      players,
+		Phase:        game.Phase,
+		Bids:         game.Bids,
+		Turns:        game.Turns,
+		Scores:       game.Scores,
+		Points:       game.Points,
+		Root:         game.Root,
+		Redeals:      game.Redeals,
+		Target:       game.Target,
+		Winner:       game.Winner,
+		Declarations: game.Declarations,
+		Rules:        game.Rules,
+		Trump:        game.trump(),
+		LegalCards:   game.LegalCards(playerName),
+		LegalBids:    game.LegalBids(playerName),
+		Spectators:   game.Spectators,
+		Dealer:       game.Dealer,
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewFor(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should only keep the hand of the recipient", func(test *testing.T) {
+		game := newPlayingGame()
+
+		got := game.ViewFor("P2")
+
+		assert.Equal(4, len(got.Players))
+		assert.Equal([]CardID{C10, CJ, CQ, DK, DA, HA, S7, S8}, got.Players["P2"].Hand)
+		assert.Equal(8, got.Players["P2"].CardsCount)
+
+		for _, name := range []string{"P1", "P3", "P4"} {
+			assert.Nil(got.Players[name].Hand)
+			assert.Equal(8, got.Players[name].CardsCount)
+		}
+	})
+
+	test.Run("should keep teams and orders of every player", func(test *testing.T) {
+		game := newPlayingGame()
+
+		got := game.ViewFor("P1")
+
+		assert.Equal("even", got.Players["P4"].Team)
+		assert.Equal(4, got.Players["P4"].Order)
+		assert.Equal(4, got.Players["P4"].InitialOrder)
+	})
+
+	test.Run("should count the cards left after a play", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Play("P1", C7)
+		assert.NoError(err)
+
+		got := game.ViewFor("P2")
+
+		assert.Equal(7, got.Players["P1"].CardsCount)
+		assert.Equal(1, len(got.Turns))
+	})
+
+	test.Run("should not show any hand to an unknown player", func(test *testing.T) {
+		game := newPlayingGame()
+
+		got := game.ViewFor("")
+
+		for _, seat := range got.Players {
+			assert.Nil(seat.Hand)
+			assert.Equal(8, seat.CardsCount)
+		}
+	})
+
+	test.Run("should give the legal moves of the recipient only", func(test *testing.T) {
+		game := newPlayingGame()
+
+		err := game.Play("P1", C7)
+		assert.NoError(err)
+
+		assert.Equal([]CardID{C10, CJ, CQ}, game.ViewFor("P2").LegalCards)
+		assert.Equal([]CardID{}, game.ViewFor("P3").LegalCards)
+		assert.Equal([]CardID{}, game.ViewFor("").LegalCards)
+	})
+
+	test.Run("should give the legal bids of the recipient", func(test *testing.T) {
+		game := newBiddingGame()
+
+		assert.True(game.ViewFor("P1").LegalBids.CanPass)
+		assert.False(game.ViewFor("P2").LegalBids.CanPass)
+	})
+}
@@ -24,21 +24,23 @@ const testLogPrefix = "\n\n ****** TEST: "
 
 type IntegrationTestSuite struct {
 	suite.Suite
-	db           *sqlx.DB
-	dbName       string
-	router       *gin.Engine
-	gameUsecases *usecases.GameUsecases
-	server1      *httptest.Server
-	server2      *httptest.Server
-	server3      *httptest.Server
-	server4      *httptest.Server
-	connection1  *websocket.Conn
-	connection2  *websocket.Conn
-	connection3  *websocket.Conn
-	connection4  *websocket.Conn
-	hub          *api.Hub
-	lastTestGame domain.Game
-	postgres     *epg.EmbeddedPostgres
+	db            *sqlx.DB
+	dbName        string
+	router        *gin.Engine
+	gameUsecases  *usecases.GameUsecases
+	userUsecases  *usecases.UserUsecases
+	server1       *httptest.Server
+	server2       *httptest.Server
+	server3       *httptest.Server
+	server4       *httptest.Server
+	connection1   *websocket.Conn
+	connection2   *websocket.Conn
+	connection3   *websocket.Conn
+	connection4   *websocket.Conn
+	hub           *api.Hub
+	lastTestGame  domain.Game
+	lastTestViews map[string]domain.Game
+	postgres      *epg.EmbeddedPostgres
 }
 
 func TestIntegrationSuite(test *testing.T) {
@@ -54,9 +56,24 @@ func (s *IntegrationTestSuite) SetupSuite() {
 		s.T().Fatal(err)
 	}
 
+	userRepository, err := repository.NewUserRepositoryFromDb(s.db)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	tournamentRepository, err := repository.NewTournamentRepositoryFromDb(s.db)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
 	s.gameUsecases = &usecases.GameUsecases{Repo: gameRepository}
+	s.userUsecases, err = usecases.NewUserUsecases(userRepository, []byte("a secret long enough to sign the tokens"))
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	tournamentUsecases := usecases.NewTournamentUsecases(tournamentRepository, s.gameUsecases)
 
-	s.router, s.hub = api.SetupRouter(s.gameUsecases, []string{})
+	s.router, s.hub = api.SetupRouter(s.gameUsecases, s.userUsecases, tournamentUsecases, []string{})
 }
 
 func (s *IntegrationTestSuite) TearDownSuite() {
@@ -81,7 +98,13 @@ func (s *IntegrationTestSuite) TestCreateGame() {
 
 	test.Run("create game", func(test *testing.T) {
 		fmt.Println(testLogPrefix, "create game")
-		s.router.ServeHTTP(httptest.NewRecorder(), testUtilities.NewCreateGameRequest(test, "NEW GAME"))
+		token, err := s.userUsecases.Register("P1", "password")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		request := testUtilities.Authorize(testUtilities.NewCreateGameRequest(test, "NEW GAME"), token)
+		s.router.ServeHTTP(httptest.NewRecorder(), request)
 
 		assert.Equal(http.StatusOK, response.Code)
 	})
@@ -200,7 +223,7 @@ func (s *IntegrationTestSuite) TestCreateGame() {
 		api.ReceiveMultipleGameOrFatal(s.connection4, test, 4)
 		got := api.ReceiveGameOrFatal(s.connection1, test)
 
-		assert.Equal(map[string]domain.Player{"P1": {Team: "Odd", Hand: []domain.CardID{}}, "P2": {Team: "Even", Hand: []domain.CardID{}}, "P3": {Team: "Odd", Hand: []domain.CardID{}}, "P4": {Team: "Even", Hand: []domain.CardID{}}}, got.Players)
+		assert.Equal(map[string]domain.Player{"P1": {Team: "Odd", Hand: []domain.CardID{}}, "P2": {Team: "Even"}, "P3": {Team: "Odd"}, "P4": {Team: "Even"}}, got.Players)
 	})
 
 	test.Run("start game", func(test *testing.T) {
@@ -214,16 +237,30 @@ func (s *IntegrationTestSuite) TestCreateGame() {
 
 		assert.Equal(1, got.ID)
 		assert.Equal("NEW GAME", got.Name)
-		assert.Equal(domain.Bidding, got.Phase)
-		assert.Equal(map[domain.BidValue]domain.Bid{}, got.Bids)
-		assert.Equal(0, len(got.Deck))
-		assert.Equal(8, len(got.Players["P1"].Hand))
+		assert.Equal(domain.Cutting, got.Phase)
+		assert.Equal("P4", got.Dealer)
+		assert.Equal([]domain.BidAction{}, got.Bids)
 		assert.Equal(1, got.Players["P1"].Order)
 		assert.Equal(2, got.Players["P2"].Order)
 		assert.Equal(3, got.Players["P3"].Order)
 		assert.Equal(4, got.Players["P4"].Order)
 	})
 
+	test.Run("cut the deck", func(test *testing.T) {
+		fmt.Println(testLogPrefix, "cut the deck")
+		api.SendMessageOrFatal(s.connection1, "cut: 16", "P1", test)
+
+		got := api.ReceiveGameOrFatal(s.connection1, test)
+		api.ReceiveGameOrFatal(s.connection2, test)
+		api.ReceiveGameOrFatal(s.connection3, test)
+		api.ReceiveGameOrFatal(s.connection4, test)
+
+		assert.Equal(domain.Bidding, got.Phase)
+		assert.Equal(0, len(got.Deck))
+		assert.Equal(8, len(got.Players["P1"].Hand))
+		assert.Equal(1, got.Players["P1"].Order)
+	})
+
 	test.Run("place some bids", func(test *testing.T) {
 		fmt.Println(testLogPrefix, "place some bids")
 		api.SendMessageOrFatal(s.connection1, "bid: spade,80", "P1", test)
@@ -234,11 +271,7 @@ func (s *IntegrationTestSuite) TestCreateGame() {
 		api.ReceiveGameOrFatal(s.connection3, test)
 
 		assert.Equal(1, got.ID)
-		assert.Equal(1, len(got.Bids))
-		assert.Equal(0, got.Bids[domain.Eighty].Coinche)
-		assert.Equal(0, got.Bids[domain.Eighty].Pass)
-		assert.Equal(domain.Spade, got.Bids[domain.Eighty].Color)
-		assert.Equal("P1", got.Bids[domain.Eighty].Player)
+		assert.Equal([]domain.BidAction{{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Spade}}, got.Bids)
 	})
 
 	test.Run("place some bids with error", func(test *testing.T) {
@@ -275,16 +308,15 @@ func (s *IntegrationTestSuite) TestCreateGame() {
 		got := api.ReceiveGameOrFatal(s.connection4, test)
 
 		assert.Equal(1, got.ID)
-		assert.Equal(2, len(got.Bids))
-		assert.Equal(1, got.Bids[domain.Ninety].Coinche)
-		assert.Equal(2, got.Bids[domain.Ninety].Pass)
-		assert.Equal(domain.Spade, got.Bids[domain.Ninety].Color)
-		assert.Equal("P3", got.Bids[domain.Ninety].Player)
+		lastBid, value := domain.GetLastBid(got.Bids)
+		assert.Equal(6, len(got.Bids))
+		assert.Equal(domain.Ninety, value)
+		assert.Equal(domain.Bid{Player: "P3", Color: domain.Spade, Coinche: 1, Pass: 2}, lastBid)
 
 		assert.Equal(domain.Playing, got.Phase)
 		assert.Equal(1, got.Players["P1"].Order)
-		assert.Equal(8, len(got.Players["P1"].Hand))
-
+		assert.Equal(8, len(got.Players["P4"].Hand))
+		assert.Equal(0, len(got.Players["P1"].Hand))
 	})
 
 	test.Run("other players are notified when a player leaves", func(test *testing.T) {
@@ -316,11 +348,10 @@ func (s *IntegrationTestSuite) TestCreateGame() {
 		got := api.ReceiveGameOrFatal(s.connection1, test)
 
 		assert.Equal(1, got.ID)
-		assert.Equal(2, len(got.Bids))
-		assert.Equal(1, got.Bids[domain.Ninety].Coinche)
-		assert.Equal(2, got.Bids[domain.Ninety].Pass)
-		assert.Equal(domain.Spade, got.Bids[domain.Ninety].Color)
-		assert.Equal("P3", got.Bids[domain.Ninety].Player)
+		lastBid, value := domain.GetLastBid(got.Bids)
+		assert.Equal(6, len(got.Bids))
+		assert.Equal(domain.Ninety, value)
+		assert.Equal(domain.Bid{Player: "P3", Color: domain.Spade, Coinche: 1, Pass: 2}, lastBid)
 
 		assert.Equal(domain.Playing, got.Phase)
 		assert.Equal(1, got.Players["P1"].Order)
@@ -342,26 +373,32 @@ func (s *IntegrationTestSuite) TestPlayGame() {
 
 		api.SendMessageOrFatal(s.connection1, fmt.Sprint("play: ", card), "P1", test)
 
-		api.ReceiveGameOrFatal(s.connection1, test)
-		api.ReceiveGameOrFatal(s.connection2, test)
-		api.ReceiveGameOrFatal(s.connection3, test)
+		views := map[string]domain.Game{
+			"P1": api.ReceiveGameOrFatal(s.connection1, test),
+			"P2": api.ReceiveGameOrFatal(s.connection2, test),
+			"P3": api.ReceiveGameOrFatal(s.connection3, test),
+			"P4": api.ReceiveGameOrFatal(s.connection4, test),
+		}
 
-		got := api.ReceiveGameOrFatal(s.connection4, test)
+		got := views["P4"]
 
 		assert.Equal(1, len(got.Turns))
 
-		assert.Equal(7, len(got.Players["P1"].Hand))
-		assert.Equal(8, len(got.Players["P2"].Hand))
-		assert.Equal(8, len(got.Players["P3"].Hand))
-		assert.Equal(8, len(got.Players["P4"].Hand))
+		assert.Equal(7, len(views["P1"].Players["P1"].Hand))
+		assert.Equal(8, len(views["P2"].Players["P2"].Hand))
+		assert.Equal(8, len(views["P3"].Players["P3"].Hand))
+		assert.Equal(8, len(views["P4"].Players["P4"].Hand))
+		assert.Equal(0, len(got.Players["P1"].Hand))
 
 		assert.Equal(4, got.Players["P1"].Order)
 		s.lastTestGame = got
+		s.lastTestViews = views
 	})
 
 	test.Run("can play all cards", func(test *testing.T) {
 		fmt.Println(testLogPrefix, "can play all cards")
 		game := s.lastTestGame
+		views := s.lastTestViews
 		connections := []*websocket.Conn{s.connection1, s.connection2, s.connection3, s.connection4}
 
 		for t := 0; t < 8; t++ {
@@ -370,7 +407,7 @@ func (s *IntegrationTestSuite) TestPlayGame() {
 
 			for _, playerName := range sortedPlayerNames {
 				p := testUtilities.GetPlayerIndexFromNameOrFatal(playerName, test)
-				playerHand := game.Players[playerName].Hand
+				playerHand := views[playerName].Players[playerName].Hand
 
 				for c := 0; c < len(playerHand); c++ {
 					card := string(playerHand[c])
@@ -379,8 +416,13 @@ func (s *IntegrationTestSuite) TestPlayGame() {
 
 					message, newGame := api.ReceiveMessageOrGameOrFatal(connections[p], test)
 
-					if message == "" { // We did not receive an error message, so we can update the game
-						api.EmtpyGamesForOtherPlayersOrFatal(sortedPlayerNames, playerName, 1, test, connections)
+					if message == "" { // We did not receive an error message, so we can update the views
+						views[playerName] = newGame
+						for _, name := range sortedPlayerNames {
+							if name != playerName {
+								views[name] = api.ReceiveGameOrFatal(connections[testUtilities.GetPlayerIndexFromNameOrFatal(name, test)], test)
+							}
+						}
 						game = newGame
 						break
 					}
@@ -388,10 +430,10 @@ func (s *IntegrationTestSuite) TestPlayGame() {
 			}
 		}
 
-		assert.Equal(0, len(game.Players["P1"].Hand))
-		assert.Equal(0, len(game.Players["P2"].Hand))
-		assert.Equal(0, len(game.Players["P3"].Hand))
-		assert.Equal(0, len(game.Players["P4"].Hand))
+		assert.Equal(0, len(views["P1"].Players["P1"].Hand))
+		assert.Equal(0, len(views["P2"].Players["P2"].Hand))
+		assert.Equal(0, len(views["P3"].Players["P3"].Hand))
+		assert.Equal(0, len(views["P4"].Players["P4"].Hand))
 
 		assert.Equal(8, len(game.Turns))
 		assert.Equal(domain.Counting, game.Phase)
@@ -428,6 +470,16 @@ func (s *IntegrationTestSuite) TestRestartGame() {
 		api.ReceiveGameOrFatal(s.connection3, test)
 		api.ReceiveGameOrFatal(s.connection4, test)
 
+		assert.Equal(domain.Cutting, got.Phase)
+		assert.Equal("P1", got.Dealer)
+
+		api.SendMessageOrFatal(s.connection2, "cut: 16", "P2", test)
+
+		got = api.ReceiveGameOrFatal(s.connection1, test)
+		view2 := api.ReceiveGameOrFatal(s.connection2, test)
+		view3 := api.ReceiveGameOrFatal(s.connection3, test)
+		view4 := api.ReceiveGameOrFatal(s.connection4, test)
+
 		assert.Equal(1, got.ID)
 		assert.Equal(0, len(got.Bids))
 		assert.Equal(0, len(got.Turns))
@@ -436,13 +488,52 @@ func (s *IntegrationTestSuite) TestRestartGame() {
 		assert.Equal(domain.Bidding, got.Phase)
 
 		assert.Equal(8, len(got.Players["P1"].Hand))
-		assert.Equal(8, len(got.Players["P2"].Hand))
-		assert.Equal(8, len(got.Players["P3"].Hand))
-		assert.Equal(8, len(got.Players["P4"].Hand))
+		assert.Equal(8, len(view2.Players["P2"].Hand))
+		assert.Equal(8, len(view3.Players["P3"].Hand))
+		assert.Equal(8, len(view4.Players["P4"].Hand))
+		assert.Equal(0, len(got.Players["P2"].Hand))
 
 		assert.Equal(1, got.Players["P2"].Order)
 		assert.Equal(1, got.Players["P2"].InitialOrder)
 	})
+
+	test.Run("redeal when everyone passes", func(test *testing.T) {
+		fmt.Println(testLogPrefix, "redeal when everyone passes")
+		connections := map[string]*websocket.Conn{"P1": s.connection1, "P2": s.connection2, "P3": s.connection3, "P4": s.connection4}
+
+		for _, name := range []string{"P2", "P3", "P4"} {
+			api.SendMessageOrFatal(connections[name], "bid: pass", name, test)
+			time.Sleep(50 * time.Millisecond) // wait to prevent submitting bid at the same time
+		}
+		api.SendMessageOrFatal(s.connection1, "bid: pass", "P1", test)
+
+		for _, connection := range connections {
+			api.ReceiveMultipleGameOrFatal(connection, test, 3)
+			assert.Equal("redeal", api.ReceiveMessageOrFatal(connection, test))
+		}
+
+		got := api.ReceiveGameOrFatal(s.connection3, test)
+		api.ReceiveGameOrFatal(s.connection1, test)
+		api.ReceiveGameOrFatal(s.connection2, test)
+		api.ReceiveGameOrFatal(s.connection4, test)
+
+		assert.Equal(domain.Cutting, got.Phase)
+		assert.Equal("P2", got.Dealer)
+
+		api.SendMessageOrFatal(s.connection3, "cut: 16", "P3", test)
+
+		got = api.ReceiveGameOrFatal(s.connection3, test)
+		api.ReceiveGameOrFatal(s.connection1, test)
+		api.ReceiveGameOrFatal(s.connection2, test)
+		api.ReceiveGameOrFatal(s.connection4, test)
+
+		assert.Equal(domain.Bidding, got.Phase)
+		assert.Equal(1, got.Redeals)
+		assert.Equal(0, len(got.Bids))
+		assert.Equal(8, len(got.Players["P3"].Hand))
+		assert.Equal(1, got.Players["P3"].Order)
+		assert.Equal(1, got.Players["P3"].InitialOrder)
+	})
 }
 
 // TODO: TEST DISCONNECTION IN GAME
@@ -3,28 +3,130 @@ package main
 import (
 	"coinche/api"
 	repository "coinche/repository"
+	"coinche/repository/file"
+	"coinche/repository/memory"
 	"coinche/usecases"
 	"coinche/utilities"
 	"fmt"
 	"os"
+	"strings"
 )
 
+// MEMORY_SCHEME keeps the games in memory, to play locally or for demos without Postgres.
+const MEMORY_SCHEME = "memory:"
+
+// FILE_SCHEME keeps the games in journals within the directory following the scheme, to run without Postgres.
+const FILE_SCHEME = "file://"
+
+// getDSN takes the storage from STORAGE, and falls back on the Postgres connection info and database name.
+func getDSN() string {
+	storage := os.Getenv("STORAGE")
+	if storage != "" {
+		return storage
+	}
+	return os.Getenv("SQLX_POSTGRES_INFO") + " dbname=" + os.Getenv("DB_NAME")
+}
+
+// newRepositories picks the storage from the scheme of the DSN, any other DSN connecting to Postgres.
+func newRepositories(dsn string) (
+	usecases.GameRepositoryInterface,
+	usecases.UserRepositoryInterface,
+	usecases.TournamentRepositoryInterface,
+	error,
+) {
+	switch {
+	case strings.HasPrefix(dsn, MEMORY_SCHEME):
+		return memory.NewGameRepository(), memory.NewUserRepository(), memory.NewTournamentRepository(), nil
+	case strings.HasPrefix(dsn, FILE_SCHEME):
+		directory := strings.TrimPrefix(dsn, FILE_SCHEME)
+
+		gameRepository, err := file.NewGameRepository(directory)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		userRepository, err := file.NewUserRepository(directory)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		tournamentRepository, err := file.NewTournamentRepository(directory)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return gameRepository, userRepository, tournamentRepository, nil
+	}
+
+	gameRepository, err := repository.NewGameRepository(dsn)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	userRepository, err := repository.NewUserRepository(dsn)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tournamentRepository, err := repository.NewTournamentRepository(dsn)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return gameRepository, userRepository, tournamentRepository, nil
+}
+
 func main() {
 	utilities.LoadEnv("")
-	connectionInfo := os.Getenv("SQLX_POSTGRES_INFO")
-	dbName := os.Getenv("DB_NAME")
 	addr := os.Getenv("PORT")
 	authorizedOrigin := os.Getenv("AUTHORIZED_ORIGIN")
+	tokenSecret := os.Getenv("TOKEN_SECRET")
+	dsn := getDSN()
 
-	dsn := connectionInfo + " dbname=" + dbName
-	gameRepository, err := repository.NewGameRepository(dsn)
+	if len(os.Args) > 1 && os.Args[1] == MIGRATE_COMMAND {
+		err := runMigrateCommand(os.Args[2:], dsn)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cutTimeout, err := utilities.GetEnvSeconds("CUT_TIMEOUT")
+	if err != nil {
+		panic(err)
+	}
+
+	bidTimeout, err := utilities.GetEnvSeconds("BID_TIMEOUT")
+	if err != nil {
+		panic(err)
+	}
+
+	playTimeout, err := utilities.GetEnvSeconds("PLAY_TIMEOUT")
+	if err != nil {
+		panic(err)
+	}
+
+	spectatorDelay, err := utilities.GetEnvSeconds("SPECTATOR_DELAY")
+	if err != nil {
+		panic(err)
+	}
+
+	gameRepository, userRepository, tournamentRepository, err := newRepositories(dsn)
 	if err != nil {
 		panic(err)
 	}
 
 	gameUsecases := usecases.NewGameUsecases(gameRepository)
+	userUsecases, err := usecases.NewUserUsecases(userRepository, []byte(tokenSecret))
+	if err != nil {
+		panic(err)
+	}
+	tournamentUsecases := usecases.NewTournamentUsecases(tournamentRepository, gameUsecases)
 
-	router, _ := api.SetupRouter(gameUsecases, []string{authorizedOrigin})
+	router, hub := api.SetupRouter(gameUsecases, userUsecases, tournamentUsecases, []string{authorizedOrigin})
+	hub.SetTurnDurations(api.TurnDurations{Cut: cutTimeout, Bid: bidTimeout, Play: playTimeout})
+	hub.SetSpectatorDelay(spectatorDelay)
 
 	fmt.Println("Listening on ", addr)
 	err = router.Run(addr)
@@ -0,0 +1,69 @@
+package main
+
+import (
+	repository "coinche/repository"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const MIGRATE_COMMAND = "migrate"
+
+const migrateUsage = "Usage: coinche migrate [status|up]"
+
+func printMigrationStatuses(db *sqlx.DB) error {
+	statuses, err := repository.GetMigrationStatuses(db)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	return writer.Flush()
+}
+
+func applyMigrations(db *sqlx.DB) error {
+	migrations, err := repository.Migrate(db)
+	if err != nil {
+		return err
+	}
+
+	if len(migrations) == 0 {
+		fmt.Println("The schema is up to date")
+	}
+	for _, migration := range migrations {
+		fmt.Println("Applied ", migration.Version, " ", migration.Name)
+	}
+
+	return nil
+}
+
+// runMigrateCommand shows the status of the migrations, or applies them without starting the server.
+func runMigrateCommand(args []string, dsn string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	db := sqlx.MustOpen("pgx", dsn)
+	defer db.Close()
+
+	switch args[0] {
+	case "status":
+		return printMigrationStatuses(db)
+	case "up":
+		return applyMigrations(db)
+	}
+
+	return errors.New(migrateUsage)
+}
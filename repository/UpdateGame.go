@@ -2,38 +2,113 @@ package repository
 
 import (
 	"coinche/domain"
+	"coinche/usecases"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
 )
 
-func (r *GameRepository) UpdateGame(game domain.Game) error {
+// checkUpdated fails when no row has been updated, the version of the game having changed since it has been read.
+func checkUpdated(result sql.Result) error {
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New(usecases.ErrVersionConflict)
+	}
+	return nil
+}
+
+func (r *GameRepository) UpdateGame(game domain.Game, events ...domain.Event) error {
 	tx := r.db.MustBegin()
 
+	err := updateGame(tx, game, events)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ArchiveAndUpdateGame creates the archive in the transaction of the update, whose version check rolls it back.
+func (r *GameRepository) ArchiveAndUpdateGame(game domain.Game, events ...domain.Event) error {
+	stored, err := r.GetGame(game.ID)
+	if err != nil {
+		return err
+	}
+	if stored.Version != game.Version {
+		return errors.New(usecases.ErrVersionConflict)
+	}
+
+	tx := r.db.MustBegin()
+
+	err = updateGame(tx, game, events)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = createGame(stored, tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func updateGame(tx *sqlx.Tx, game domain.Game, events []domain.Event) error {
 	deck, err := json.Marshal(game.Deck)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.Exec(
+	rules, err := json.Marshal(game.Rules)
+	if err != nil {
+		return err
+	}
+
+	spectators, err := json.Marshal(getSpectators(game))
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(
 		`
 		UPDATE game
-		SET phase = $2, Deck = $3, Root = $4
-		WHERE id = $1
+		SET phase = $2, Deck = $3, Root = $4, Redeals = $5, Target = $6, Winner = $7, Rules = $8, Spectators = $9,
+			Dealer = $11, Draws = $12, version = version + 1
+		WHERE id = $1 AND version = $10
 		`,
 		game.ID,
 		game.Phase,
 		deck,
 		game.Root,
+		game.Redeals,
+		game.Target,
+		game.Winner,
+		rules,
+		spectators,
+		game.Version,
+		game.Dealer,
+		game.Draws,
 	)
+	if err != nil {
+		return err
+	}
 
+	err = checkUpdated(result)
 	if err != nil {
 		return err
 	}
 
 	if game.Bids != nil && len(game.Bids) == 0 {
-		err = resetItems(tx, game.ID, "bid")
+		err = resetItems(tx, game.ID, "bid_action")
 		if err != nil {
 			return err
 		}
@@ -56,6 +131,18 @@ func (r *GameRepository) UpdateGame(game domain.Game) error {
 		}
 	}
 
+	if game.Declarations != nil && len(game.Declarations) == 0 {
+		err = resetItems(tx, game.ID, "declaration")
+		if err != nil {
+			return err
+		}
+	} else {
+		err = updateDeclarations(tx, game.ID, game.Declarations)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = updatePlayers(tx, game.ID, game.Players)
 	if err != nil {
 		return err
@@ -78,7 +165,7 @@ func (r *GameRepository) UpdateGame(game domain.Game) error {
 		return err
 	}
 
-	return tx.Commit()
+	return createEvents(tx, game.ID, events)
 }
 
 func resetItems(tx *sqlx.Tx, gameID int, collection string) error {
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"coinche/domain"
+	"time"
+)
+
+// AddChatMessage also deletes the messages beyond the history size, so the table stays bounded for each game.
+func (s *GameRepository) AddChatMessage(gameID int, message domain.ChatMessage) error {
+	tx := s.db.MustBegin()
+
+	_, err := tx.Exec(
+		`
+		INSERT INTO chat (gameid, sender, kind, content)
+		VALUES ($1, $2, $3, $4)
+		`,
+		gameID,
+		message.Sender,
+		message.Kind,
+		message.Content,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		`
+		DELETE FROM chat
+		WHERE gameid = $1 AND id NOT IN (
+			SELECT id FROM chat WHERE gameid = $1 ORDER BY id DESC LIMIT $2
+		)
+		`,
+		gameID,
+		domain.CHAT_HISTORY_SIZE,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *GameRepository) GetChatMessages(gameID int) ([]domain.ChatMessage, error) {
+	messages := []domain.ChatMessage{}
+
+	type DBChatMessage struct {
+		Sender    string
+		Kind      string
+		Content   string
+		CreatedAt time.Time
+	}
+
+	var dbMessages []DBChatMessage
+
+	err := s.db.Select(&dbMessages, `SELECT sender, kind, content, createdAt FROM chat WHERE gameid=$1 ORDER BY id`, gameID)
+	if err != nil {
+		return messages, err
+	}
+
+	for _, dbMessage := range dbMessages {
+		messages = append(messages, domain.ChatMessage{
+			Sender:    dbMessage.Sender,
+			Kind:      domain.ChatKind(dbMessage.Kind),
+			Content:   dbMessage.Content,
+			CreatedAt: dbMessage.CreatedAt,
+		})
+	}
+
+	return messages, nil
+}
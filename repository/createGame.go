@@ -7,6 +7,14 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// getSpectators never gives nil, which would be stored as a json null.
+func getSpectators(game domain.Game) []string {
+	if game.Spectators == nil {
+		return []string{}
+	}
+	return game.Spectators
+}
+
 func createGame(game domain.Game, tx *sqlx.Tx) (int, error) {
 	var gameID int
 
@@ -15,15 +23,33 @@ func createGame(game domain.Game, tx *sqlx.Tx) (int, error) {
 		return 0, err
 	}
 
+	rules, err := json.Marshal(game.Rules)
+	if err != nil {
+		return 0, err
+	}
+
+	spectators, err := json.Marshal(getSpectators(game))
+	if err != nil {
+		return 0, err
+	}
+
 	err = tx.QueryRow(
 		`
-		INSERT INTO game (name, phase, deck) 
-		VALUES ($1, $2, $3) 
+		INSERT INTO game (name, phase, deck, redeals, target, winner, rules, spectators, dealer, seed, draws) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) 
 		RETURNING id
 		`,
 		game.Name,
 		game.Phase,
 		deck,
+		game.Redeals,
+		game.Target,
+		game.Winner,
+		rules,
+		spectators,
+		game.Dealer,
+		game.Seed,
+		game.Draws,
 	).Scan(&gameID)
 	if err != nil {
 		return 0, err
@@ -64,6 +90,11 @@ func createGame(game domain.Game, tx *sqlx.Tx) (int, error) {
 		return 0, err
 	}
 
+	err = createDeclarations(tx, gameID, game.Declarations)
+	if err != nil {
+		return 0, err
+	}
+
 	err = createAndUpdateScores(tx, gameID, game.Scores)
 	if err != nil {
 		return 0, err
@@ -77,7 +108,7 @@ func createGame(game domain.Game, tx *sqlx.Tx) (int, error) {
 	return gameID, nil
 }
 
-func (s *GameRepository) CreateGame(game domain.Game) (int, error) {
+func (s *GameRepository) CreateGame(game domain.Game, events ...domain.Event) (int, error) {
 	tx := s.db.MustBegin()
 
 	gameID, err := createGame(game, tx)
@@ -85,5 +116,10 @@ func (s *GameRepository) CreateGame(game domain.Game) (int, error) {
 		return 0, err
 	}
 
+	err = createEvents(tx, gameID, events)
+	if err != nil {
+		return 0, err
+	}
+
 	return gameID, tx.Commit()
 }
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"coinche/domain"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func createDeclaration(declaration domain.Declaration, tx *sqlx.Tx, gameID int, position int) error {
+	cards, err := json.Marshal(declaration.Cards)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`
+			INSERT INTO declaration (gameid, player, cards, position)
+			VALUES ($1, $2, $3, $4)
+			`,
+		gameID,
+		declaration.Player,
+		cards,
+		position,
+	)
+	return err
+}
+
+func createDeclarations(tx *sqlx.Tx, gameID int, declarations []domain.Declaration) error {
+	for position, declaration := range declarations {
+		err := createDeclaration(declaration, tx, gameID, position)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateDeclarations(tx *sqlx.Tx, gameID int, declarations []domain.Declaration) error {
+	count, err := countDocumentsInGame(tx, gameID, "declaration")
+	if err != nil {
+		return err
+	}
+
+	if count > len(declarations) {
+		count = len(declarations)
+	}
+
+	for index, declaration := range declarations[count:] {
+		err := createDeclaration(declaration, tx, gameID, index+count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getDeclarations(tx *sqlx.Tx, gameID int) ([]domain.Declaration, error) {
+	declarations := []domain.Declaration{}
+
+	type DBDeclaration struct {
+		Player string
+		Cards  []byte
+	}
+
+	var dbDeclarations []DBDeclaration
+
+	err := tx.Select(&dbDeclarations, `SELECT player, cards FROM declaration WHERE gameid=$1 ORDER BY position`, gameID)
+	if err != nil {
+		return declarations, err
+	}
+
+	for _, dbDeclaration := range dbDeclarations {
+		var cards []domain.CardID
+		err := json.Unmarshal(dbDeclaration.Cards, &cards)
+		if err != nil {
+			return declarations, err
+		}
+
+		declarations = append(declarations, domain.Declaration{
+			Player: dbDeclaration.Player,
+			Cards:  cards,
+		})
+	}
+
+	return declarations, nil
+}
@@ -1,9 +1,12 @@
 package repository
 
-import "errors"
+import (
+	"coinche/usecases"
+	"errors"
+)
 
 const (
-	ErrCannotDeleteWithPlayers = "CANNOT DELETE GAME WITH PLAYERS"
+	ErrCannotDeleteWithPlayers = usecases.ErrCannotDeleteWithPlayers
 )
 
 func (s *GameRepository) DeleteGame(gameID int) error {
@@ -19,6 +22,16 @@ func (s *GameRepository) DeleteGame(gameID int) error {
 		return errors.New(ErrCannotDeleteWithPlayers)
 	}
 
+	err = resetItems(tx, gameID, "event")
+	if err != nil {
+		return err
+	}
+
+	err = resetItems(tx, gameID, "chat")
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(`DELETE FROM game WHERE id=$1`, gameID)
 	if err != nil {
 		return err
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"coinche/domain"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func createEvent(tx *sqlx.Tx, gameID int, event domain.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`
+		INSERT INTO event (gameid, sequence, player, kind, payload)
+		VALUES ($1, (SELECT COALESCE(MAX(sequence), 0) + 1 FROM event WHERE gameid = $1), $2, $3, $4)
+		`,
+		gameID,
+		event.Player,
+		event.Kind,
+		payload,
+	)
+	return err
+}
+
+func createEvents(tx *sqlx.Tx, gameID int, events []domain.Event) error {
+	for _, event := range events {
+		err := createEvent(tx, gameID, event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GameRepository) GetEvents(gameID int) ([]domain.Event, error) {
+	events := []domain.Event{}
+
+	type DBEvent struct {
+		Sequence  int
+		Player    string
+		Kind      string
+		Payload   []byte
+		CreatedAt time.Time
+	}
+
+	var dbEvents []DBEvent
+
+	err := s.db.Select(&dbEvents, `SELECT sequence, player, kind, payload, createdAt FROM event WHERE gameid=$1 ORDER BY sequence`, gameID)
+	if err != nil {
+		return events, err
+	}
+
+	for _, dbEvent := range dbEvents {
+		var payload domain.EventPayload
+		err := json.Unmarshal(dbEvent.Payload, &payload)
+		if err != nil {
+			return events, err
+		}
+
+		events = append(events, domain.Event{
+			Sequence:  dbEvent.Sequence,
+			Player:    dbEvent.Player,
+			Kind:      domain.EventKind(dbEvent.Kind),
+			Payload:   payload,
+			CreatedAt: dbEvent.CreatedAt,
+		})
+	}
+
+	return events, nil
+}
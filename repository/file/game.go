@@ -0,0 +1,337 @@
+package file
+
+import (
+	"coinche/domain"
+	"coinche/repository/memory"
+	"coinche/usecases"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const GAMES_JOURNAL = "games.jsonl"
+
+// COMPACTION_THRESHOLD is the number of records after which the journal is rewritten with the current state only.
+const COMPACTION_THRESHOLD = 1000
+
+type recordKind string
+
+const (
+	saveRecord   recordKind = "save"
+	deleteRecord recordKind = "delete"
+	chatRecord   recordKind = "chat"
+	lastIDRecord recordKind = "lastID"
+)
+
+// record holds the snapshot of a game with the events of the command which has saved it, along with the archive of
+// its finished deal when a new deal is started, or the chat messages added to a game. A compacted journal starts with
+// the last ID given, so the ID of a deleted game is never given again.
+type record struct {
+	Kind    recordKind
+	GameID  int
+	Game    *domain.Game         `json:",omitempty"`
+	Archive *domain.Game         `json:",omitempty"`
+	Events  []domain.Event       `json:",omitempty"`
+	Chats   []domain.ChatMessage `json:",omitempty"`
+}
+
+// GameRepository stores the games in a journal of snapshots in a local directory, and keeps the current state in
+// memory to read it.
+type GameRepository struct {
+	usecases.GameRepositoryInterface
+	mu      sync.Mutex
+	journal *journal
+	lastID  int
+	games   map[int]domain.Game
+	events  map[int][]domain.Event
+	chats   map[int][]domain.ChatMessage
+}
+
+func NewGameRepository(directory string) (*GameRepository, error) {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &GameRepository{
+		games:  make(map[int]domain.Game),
+		events: make(map[int][]domain.Event),
+		chats:  make(map[int][]domain.ChatMessage),
+	}
+
+	r.journal, err = openJournal(filepath.Join(directory, GAMES_JOURNAL), r.applyLine)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *GameRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.journal.close()
+}
+
+func (r *GameRepository) applyLine(line []byte) error {
+	var rec record
+	err := json.Unmarshal(line, &rec)
+	if err != nil {
+		return err
+	}
+
+	switch rec.Kind {
+	case saveRecord:
+		if rec.Archive != nil {
+			r.games[rec.Archive.ID] = *rec.Archive
+			if rec.Archive.ID > r.lastID {
+				r.lastID = rec.Archive.ID
+			}
+		}
+		r.games[rec.GameID] = *rec.Game
+		r.events[rec.GameID] = append(r.events[rec.GameID], rec.Events...)
+		if rec.GameID > r.lastID {
+			r.lastID = rec.GameID
+		}
+	case deleteRecord:
+		delete(r.games, rec.GameID)
+		delete(r.events, rec.GameID)
+		delete(r.chats, rec.GameID)
+	case chatRecord:
+		messages := append(r.chats[rec.GameID], rec.Chats...)
+		if len(messages) > domain.CHAT_HISTORY_SIZE {
+			messages = messages[len(messages)-domain.CHAT_HISTORY_SIZE:]
+		}
+		r.chats[rec.GameID] = messages
+	case lastIDRecord:
+		if rec.GameID > r.lastID {
+			r.lastID = rec.GameID
+		}
+	}
+
+	return nil
+}
+
+// write applies what has been written to the journal, so the state in memory never differs from the disk.
+func (r *GameRepository) write(rec record) error {
+	line, err := r.journal.append(rec)
+	if err != nil {
+		return err
+	}
+
+	err = r.applyLine(line)
+	if err != nil {
+		return err
+	}
+
+	if r.journal.lines >= COMPACTION_THRESHOLD {
+		err = r.compact()
+		if err != nil {
+			fmt.Println("Could not compact the games journal: ", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *GameRepository) compact() error {
+	gameIDs := []int{}
+	for gameID := range r.games {
+		gameIDs = append(gameIDs, gameID)
+	}
+	sort.Ints(gameIDs)
+
+	records := []interface{}{record{Kind: lastIDRecord, GameID: r.lastID}}
+	for _, gameID := range gameIDs {
+		game := r.games[gameID]
+		records = append(records, record{Kind: saveRecord, GameID: gameID, Game: &game, Events: r.events[gameID]})
+
+		if len(r.chats[gameID]) > 0 {
+			records = append(records, record{Kind: chatRecord, GameID: gameID, Chats: r.chats[gameID]})
+		}
+	}
+
+	return r.journal.compact(records)
+}
+
+func (r *GameRepository) numberEvents(gameID int, events []domain.Event) []domain.Event {
+	numbered := []domain.Event{}
+	for _, event := range events {
+		event.Sequence = len(r.events[gameID]) + len(numbered) + 1
+		numbered = append(numbered, event)
+	}
+	return numbered
+}
+
+func (r *GameRepository) save(game domain.Game, events []domain.Event) error {
+	return r.write(record{Kind: saveRecord, GameID: game.ID, Game: &game, Events: r.numberEvents(game.ID, events)})
+}
+
+func (r *GameRepository) getGame(gameID int) (domain.Game, error) {
+	game, ok := r.games[gameID]
+	if !ok {
+		return domain.Game{}, errors.New(usecases.ErrGameNotFound)
+	}
+	return memory.CopyGame(game)
+}
+
+func (r *GameRepository) GetGame(gameID int) (domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.getGame(gameID)
+}
+
+// ListGames only gives the current deal of each game, the archived deals having another game as root.
+func (r *GameRepository) ListGames() ([]domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	gameIDs := []int{}
+	for gameID, game := range r.games {
+		if game.Root == gameID {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	sort.Ints(gameIDs)
+
+	games := []domain.Game{}
+	for _, gameID := range gameIDs {
+		game, err := r.getGame(gameID)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+func (r *GameRepository) CreateGame(game domain.Game, events ...domain.Event) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := game
+	created.ID = r.lastID + 1
+	created.CreatedAt = time.Now()
+	created.Version = 0
+	if created.Root == 0 {
+		created.Root = created.ID
+	}
+
+	err := r.save(created, events)
+	if err != nil {
+		return 0, err
+	}
+
+	return created.ID, nil
+}
+
+func (r *GameRepository) UpdatePlayer(gameID int, version int, playerName string, player domain.Player, events ...domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.games[gameID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	updated, err := memory.MergePlayerUpdate(stored, version, playerName, player)
+	if err != nil {
+		return err
+	}
+
+	return r.save(updated, events)
+}
+
+func (r *GameRepository) UpdateGame(game domain.Game, events ...domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.games[game.ID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	updated, err := memory.MergeUpdate(stored, game)
+	if err != nil {
+		return err
+	}
+
+	return r.save(updated, events)
+}
+
+func (r *GameRepository) ArchiveAndUpdateGame(game domain.Game, events ...domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.games[game.ID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	updated, err := memory.MergeUpdate(stored, game)
+	if err != nil {
+		return err
+	}
+
+	archive, err := memory.CopyGame(stored)
+	if err != nil {
+		return err
+	}
+	archive.ID = r.lastID + 1
+	archive.CreatedAt = time.Now()
+	archive.Version = 0
+
+	return r.write(record{
+		Kind:    saveRecord,
+		GameID:  updated.ID,
+		Game:    &updated,
+		Events:  r.numberEvents(updated.ID, events),
+		Archive: &archive,
+	})
+}
+
+func (r *GameRepository) DeleteGame(gameID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	game, ok := r.games[gameID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	if len(game.Players) > 0 {
+		return errors.New(usecases.ErrCannotDeleteWithPlayers)
+	}
+
+	return r.write(record{Kind: deleteRecord, GameID: gameID})
+}
+
+func (r *GameRepository) GetEvents(gameID int) ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.Event{}, r.events[gameID]...), nil
+}
+
+func (r *GameRepository) AddChatMessage(gameID int, message domain.ChatMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.games[gameID]; !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	message.CreatedAt = time.Now()
+	return r.write(record{Kind: chatRecord, GameID: gameID, Chats: []domain.ChatMessage{message}})
+}
+
+func (r *GameRepository) GetChatMessages(gameID int) ([]domain.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.ChatMessage{}, r.chats[gameID]...), nil
+}
@@ -0,0 +1,349 @@
+package file
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openOrFatal(test *testing.T, directory string) *GameRepository {
+	gameRepository, err := NewGameRepository(directory)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Cleanup(func() {
+		gameRepository.Close()
+	})
+
+	return gameRepository
+}
+
+func TestGameRepo(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	gameRepository := openOrFatal(test, directory)
+
+	gameID, err := gameRepository.CreateGame(domain.NewGame("GAME ONE"), domain.NewEvent(domain.CreateEvent, "", domain.EventPayload{Name: "GAME ONE"}))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameRepository.UpdatePlayer(gameID, 0, "P1", domain.Player{})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	game, err := gameRepository.GetGame(gameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	game.Players["P1"] = domain.Player{Team: "A Team"}
+	err = gameRepository.UpdateGame(game, domain.NewEvent(domain.JoinTeamEvent, "P1", domain.EventPayload{Team: "A Team"}))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	game, err = gameRepository.GetGame(gameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	game.Players["P1"] = domain.Player{Team: "B Team"}
+	err = gameRepository.ArchiveAndUpdateGame(game, domain.NewEvent(domain.StartEvent, "", domain.EventPayload{}))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	message, err := domain.NewChatMessage("P1", "hello")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameRepository.AddChatMessage(gameID, message)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	deletedID, err := gameRepository.CreateGame(domain.NewGame("GAME DELETED"))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameRepository.DeleteGame(deletedID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("keep the games when opened again", func(test *testing.T) {
+		gameRepository.Close()
+		reopened := openOrFatal(test, directory)
+
+		got, err := reopened.GetGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("GAME ONE", got.Name)
+		assert.Equal("B Team", got.Players["P1"].Team)
+		assert.Equal(3, got.Version)
+
+		games, err := reopened.ListGames()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(1, len(games))
+
+		archived, err := reopened.GetGame(2)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(gameID, archived.Root)
+		assert.Equal("A Team", archived.Players["P1"].Team)
+
+		_, err = reopened.GetGame(deletedID)
+
+		assert.EqualError(err, usecases.ErrGameNotFound)
+
+		events, err := reopened.GetEvents(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(3, len(events))
+		assert.Equal(3, events[2].Sequence)
+
+		messages, err := reopened.GetChatMessages(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(1, len(messages))
+		assert.Equal("hello", messages[0].Content)
+
+		newID, err := reopened.CreateGame(domain.NewGame("GAME FOUR"))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(deletedID+1, newID)
+	})
+}
+
+func TestTornJournal(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	gameRepository := openOrFatal(test, directory)
+
+	gameID, err := gameRepository.CreateGame(domain.NewGame("GAME ONE"))
+	if err != nil {
+		test.Fatal(err)
+	}
+	gameRepository.Close()
+
+	path := filepath.Join(directory, GAMES_JOURNAL)
+	output, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	_, err = output.WriteString(`{"Kind":"save","GameID":2,"Game":{"Na`)
+	if err != nil {
+		test.Fatal(err)
+	}
+	output.Close()
+
+	test.Run("drop a line which has not been written entirely", func(test *testing.T) {
+		reopened := openOrFatal(test, directory)
+
+		games, err := reopened.ListGames()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(1, len(games))
+		assert.Equal(gameID, games[0].ID)
+
+		newID, err := reopened.CreateGame(domain.NewGame("GAME TWO"))
+		if err != nil {
+			test.Fatal(err)
+		}
+		reopened.Close()
+
+		again := openOrFatal(test, directory)
+
+		got, err := again.GetGame(newID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("GAME TWO", got.Name)
+	})
+}
+
+// failingFile writes part of the line before failing, like a disk running out of space.
+type failingFile struct {
+	journalFile
+}
+
+func (output failingFile) Write(data []byte) (int, error) {
+	written, _ := output.journalFile.Write(data[:len(data)/2])
+	return written, errors.New("NO SPACE LEFT")
+}
+
+func TestFailedAppend(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	gameRepository := openOrFatal(test, directory)
+
+	_, err := gameRepository.CreateGame(domain.NewGame("GAME ONE"))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	output := gameRepository.journal.output
+	gameRepository.journal.output = failingFile{output}
+
+	_, err = gameRepository.CreateGame(domain.NewGame("GAME LOST"))
+
+	assert.Error(err)
+
+	gameRepository.journal.output = output
+
+	test.Run("cut off the line which has failed to be written", func(test *testing.T) {
+		gameID, err := gameRepository.CreateGame(domain.NewGame("GAME TWO"))
+		if err != nil {
+			test.Fatal(err)
+		}
+		gameRepository.Close()
+
+		reopened := openOrFatal(test, directory)
+
+		games, err := reopened.ListGames()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(2, len(games))
+
+		got, err := reopened.GetGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("GAME TWO", got.Name)
+	})
+}
+
+func TestCompaction(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	gameRepository := openOrFatal(test, directory)
+
+	gameID, err := gameRepository.CreateGame(domain.NewGame("GAME ONE"))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	for i := 0; i < COMPACTION_THRESHOLD; i++ {
+		err := gameRepository.UpdatePlayer(gameID, i, "P1", domain.Player{}, domain.NewEvent(domain.PassEvent, "P1", domain.EventPayload{}))
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	message, err := domain.NewChatMessage("P1", "hello")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameRepository.AddChatMessage(gameID, message)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("rewrite the journal with the current state only", func(test *testing.T) {
+		data, err := os.ReadFile(filepath.Join(directory, GAMES_JOURNAL))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(4, strings.Count(string(data), "\n"))
+	})
+
+	test.Run("keep the state once compacted", func(test *testing.T) {
+		gameRepository.Close()
+		reopened := openOrFatal(test, directory)
+
+		got, err := reopened.GetGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(COMPACTION_THRESHOLD, got.Version)
+
+		events, err := reopened.GetEvents(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(COMPACTION_THRESHOLD, len(events))
+		assert.Equal(COMPACTION_THRESHOLD, events[len(events)-1].Sequence)
+
+		messages, err := reopened.GetChatMessages(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("hello", messages[0].Content)
+	})
+}
+
+func TestCompactionAfterDelete(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	gameRepository := openOrFatal(test, directory)
+
+	_, err := gameRepository.CreateGame(domain.NewGame("GAME ONE"))
+	if err != nil {
+		test.Fatal(err)
+	}
+	deletedID, err := gameRepository.CreateGame(domain.NewGame("GAME TWO"))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameRepository.DeleteGame(deletedID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameRepository.compact()
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("not give the ID of a deleted game again", func(test *testing.T) {
+		gameRepository.Close()
+		reopened := openOrFatal(test, directory)
+
+		newID, err := reopened.CreateGame(domain.NewGame("GAME THREE"))
+
+		assert.NoError(err)
+		assert.Equal(deletedID+1, newID)
+	})
+}
@@ -0,0 +1,192 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// journalFile is the part of the file used to append to the journal.
+type journalFile interface {
+	io.WriteSeeker
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// journal is an append-only file of JSON lines. Each line is synced to the disk before being applied, and a line torn
+// by a crash is dropped when the journal is opened again. A line which fails to be written is cut off at once, so a
+// torn line can only be the last one.
+type journal struct {
+	path   string
+	output journalFile
+	lines  int
+	size   int64
+	// broken is the error which has kept a torn line from being cut off, the journal refusing any other line.
+	broken error
+}
+
+// openJournal applies every line already written, in order, before appending new ones.
+func openJournal(path string, apply func(line []byte) error) (*journal, error) {
+	output, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(output)
+	offset := int64(0)
+	lines := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			output.Close()
+			return nil, err
+		}
+
+		err = apply(bytes.TrimSuffix(line, []byte("\n")))
+		if err != nil {
+			output.Close()
+			return nil, err
+		}
+		offset += int64(len(line))
+		lines++
+	}
+
+	// The lines after the offset are lost in any case, as they have not been synced entirely.
+	err = output.Truncate(offset)
+	if err != nil {
+		output.Close()
+		return nil, err
+	}
+
+	_, err = output.Seek(offset, io.SeekStart)
+	if err != nil {
+		output.Close()
+		return nil, err
+	}
+
+	return &journal{path: path, output: output, lines: lines, size: offset}, nil
+}
+
+// cutOff drops what has been written after the last line appended entirely.
+func (j *journal) cutOff() {
+	err := j.output.Truncate(j.size)
+	if err == nil {
+		_, err = j.output.Seek(j.size, io.SeekStart)
+	}
+	if err != nil {
+		j.broken = err
+	}
+}
+
+func (j *journal) append(value interface{}) ([]byte, error) {
+	if j.broken != nil {
+		return nil, j.broken
+	}
+
+	line, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := j.output.Write(append(line, '\n'))
+	if err != nil {
+		j.cutOff()
+		return nil, err
+	}
+
+	err = j.output.Sync()
+	if err != nil {
+		j.cutOff()
+		return nil, err
+	}
+
+	j.lines++
+	j.size += int64(written)
+	return line, nil
+}
+
+func syncDirectory(path string) error {
+	directory, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer directory.Close()
+	return directory.Sync()
+}
+
+// compact replaces the journal with the given values, through a temporary file renamed at once so a crash keeps
+// either the old or the new journal.
+func (j *journal) compact(values []interface{}) error {
+	temporaryPath := j.path + ".tmp"
+	temporary, err := os.OpenFile(temporaryPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(temporary)
+	size := int64(0)
+	for _, value := range values {
+		line, err := json.Marshal(value)
+		if err != nil {
+			temporary.Close()
+			return err
+		}
+
+		written, err := writer.Write(append(line, '\n'))
+		if err != nil {
+			temporary.Close()
+			return err
+		}
+		size += int64(written)
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		temporary.Close()
+		return err
+	}
+
+	err = temporary.Sync()
+	if err != nil {
+		temporary.Close()
+		return err
+	}
+
+	err = temporary.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(temporaryPath, j.path)
+	if err != nil {
+		return err
+	}
+
+	err = syncDirectory(filepath.Dir(j.path))
+	if err != nil {
+		return err
+	}
+
+	output, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	j.output.Close()
+	j.output = output
+	j.lines = len(values)
+	j.size = size
+	j.broken = nil
+	return nil
+}
+
+func (j *journal) close() error {
+	return j.output.Close()
+}
@@ -0,0 +1,137 @@
+package file
+
+import (
+	"coinche/domain"
+	"coinche/repository/memory"
+	"coinche/usecases"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const TOURNAMENTS_JOURNAL = "tournaments.jsonl"
+
+// TournamentRepository stores a snapshot of the tournament at each update in a journal which is never compacted, as a
+// tournament is only updated when a pair is registered or a round is started.
+type TournamentRepository struct {
+	usecases.TournamentRepositoryInterface
+	mu          sync.Mutex
+	journal     *journal
+	lastID      int
+	tournaments map[int]domain.Tournament
+}
+
+func NewTournamentRepository(directory string) (*TournamentRepository, error) {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TournamentRepository{tournaments: make(map[int]domain.Tournament)}
+
+	r.journal, err = openJournal(filepath.Join(directory, TOURNAMENTS_JOURNAL), r.applyLine)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *TournamentRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.journal.close()
+}
+
+func (r *TournamentRepository) applyLine(line []byte) error {
+	var tournament domain.Tournament
+	err := json.Unmarshal(line, &tournament)
+	if err != nil {
+		return err
+	}
+
+	r.tournaments[tournament.ID] = tournament
+	if tournament.ID > r.lastID {
+		r.lastID = tournament.ID
+	}
+
+	return nil
+}
+
+func (r *TournamentRepository) save(tournament domain.Tournament) error {
+	line, err := r.journal.append(tournament)
+	if err != nil {
+		return err
+	}
+
+	return r.applyLine(line)
+}
+
+func (r *TournamentRepository) ListTournaments() ([]domain.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournamentIDs := []int{}
+	for tournamentID := range r.tournaments {
+		tournamentIDs = append(tournamentIDs, tournamentID)
+	}
+	sort.Ints(tournamentIDs)
+
+	tournaments := []domain.Tournament{}
+	for _, tournamentID := range tournamentIDs {
+		tournament, err := memory.CopyTournament(r.tournaments[tournamentID])
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+
+	return tournaments, nil
+}
+
+func (r *TournamentRepository) CreateTournament(tournament domain.Tournament) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := tournament
+	created.ID = r.lastID + 1
+	created.CreatedAt = time.Now()
+
+	err := r.save(created)
+	if err != nil {
+		return 0, err
+	}
+
+	return created.ID, nil
+}
+
+func (r *TournamentRepository) GetTournament(tournamentID int) (domain.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournament, ok := r.tournaments[tournamentID]
+	if !ok {
+		return domain.Tournament{}, errors.New(usecases.ErrTournamentNotFound)
+	}
+
+	return memory.CopyTournament(tournament)
+}
+
+func (r *TournamentRepository) UpdateTournament(tournament domain.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tournaments[tournament.ID]
+	if !ok {
+		return errors.New(usecases.ErrTournamentNotFound)
+	}
+
+	updated := tournament
+	updated.CreatedAt = stored.CreatedAt
+
+	return r.save(updated)
+}
@@ -0,0 +1,80 @@
+package file
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournamentRepo(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	tournamentRepository, err := NewTournamentRepository(directory)
+	if err != nil {
+		test.Fatal(err)
+	}
+	tournamentID := 0
+
+	test.Run("create and update a tournament", func(test *testing.T) {
+		tournament, _ := domain.NewTournament("CUP", "", "", 0, 42)
+
+		tournamentID, err = tournamentRepository.CreateTournament(tournament)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		tournament, _ = tournamentRepository.GetTournament(tournamentID)
+		_ = tournament.AddPair("A", []string{"A1", "A2"})
+		err = tournamentRepository.UpdateTournament(tournament)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := tournamentRepository.GetTournament(tournamentID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("CUP", got.Name)
+		assert.Equal(1, len(got.Pairs))
+		assert.False(got.CreatedAt.IsZero())
+	})
+
+	test.Run("not update an unknown tournament", func(test *testing.T) {
+		err := tournamentRepository.UpdateTournament(domain.Tournament{ID: 1000})
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrTournamentNotFound, err.Error())
+	})
+
+	test.Run("keep the last state of the tournaments when opened again", func(test *testing.T) {
+		tournamentRepository.Close()
+
+		reopened, err := NewTournamentRepository(directory)
+		if err != nil {
+			test.Fatal(err)
+		}
+		defer reopened.Close()
+
+		got, err := reopened.GetTournament(tournamentID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal([]domain.Pair{{Name: "A", Players: []string{"A1", "A2"}}}, got.Pairs)
+
+		tournaments, err := reopened.ListTournaments()
+		assert.NoError(err)
+		assert.Equal(1, len(tournaments))
+
+		newID, err := reopened.CreateTournament(domain.Tournament{Name: "OTHER CUP"})
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(tournamentID+1, newID)
+	})
+}
@@ -0,0 +1,102 @@
+package file
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const USERS_JOURNAL = "users.jsonl"
+
+// userRecord keeps the password hash, which is never serialized with the user.
+type userRecord struct {
+	ID           int
+	Name         string
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+// UserRepository stores the users in a journal which is never compacted, as users are only created.
+type UserRepository struct {
+	usecases.UserRepositoryInterface
+	mu      sync.Mutex
+	journal *journal
+	lastID  int
+	users   map[string]domain.User
+}
+
+func NewUserRepository(directory string) (*UserRepository, error) {
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &UserRepository{users: make(map[string]domain.User)}
+
+	r.journal, err = openJournal(filepath.Join(directory, USERS_JOURNAL), r.applyLine)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *UserRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.journal.close()
+}
+
+func (r *UserRepository) applyLine(line []byte) error {
+	var rec userRecord
+	err := json.Unmarshal(line, &rec)
+	if err != nil {
+		return err
+	}
+
+	r.users[rec.Name] = domain.User{ID: rec.ID, Name: rec.Name, PasswordHash: rec.PasswordHash, CreatedAt: rec.CreatedAt}
+	if rec.ID > r.lastID {
+		r.lastID = rec.ID
+	}
+
+	return nil
+}
+
+func (r *UserRepository) CreateUser(user domain.User) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.Name]; ok {
+		return 0, errors.New(domain.ErrUserAlreadyExists)
+	}
+
+	rec := userRecord{ID: r.lastID + 1, Name: user.Name, PasswordHash: user.PasswordHash, CreatedAt: time.Now()}
+	line, err := r.journal.append(rec)
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.applyLine(line)
+	if err != nil {
+		return 0, err
+	}
+
+	return rec.ID, nil
+}
+
+func (r *UserRepository) GetUser(name string) (domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[name]
+	if !ok {
+		return domain.User{}, errors.New(domain.ErrUserNotFound)
+	}
+
+	return user, nil
+}
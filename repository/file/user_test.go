@@ -0,0 +1,66 @@
+package file
+
+import (
+	"coinche/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepo(test *testing.T) {
+	assert := assert.New(test)
+
+	directory := test.TempDir()
+	userRepository, err := NewUserRepository(directory)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("create and get a user", func(test *testing.T) {
+		newID, err := userRepository.CreateUser(domain.User{Name: "P1", PasswordHash: "HASH"})
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := userRepository.GetUser("P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(newID, got.ID)
+		assert.Equal("P1", got.Name)
+		assert.Equal("HASH", got.PasswordHash)
+		assert.False(got.CreatedAt.IsZero())
+	})
+
+	test.Run("not create a user twice", func(test *testing.T) {
+		_, err := userRepository.CreateUser(domain.User{Name: "P1", PasswordHash: "OTHER HASH"})
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUserAlreadyExists, err.Error())
+	})
+
+	test.Run("keep the users when opened again", func(test *testing.T) {
+		userRepository.Close()
+
+		reopened, err := NewUserRepository(directory)
+		if err != nil {
+			test.Fatal(err)
+		}
+		defer reopened.Close()
+
+		got, err := reopened.GetUser("P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("HASH", got.PasswordHash)
+
+		newID, err := reopened.CreateUser(domain.User{Name: "P2", PasswordHash: "HASH"})
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(2, newID)
+	})
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"coinche/domain"
+	"coinche/repository/file"
+	"coinche/usecases"
 	"coinche/utilities"
 	testUtilities "coinche/utilities/test"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,23 +14,25 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func NewGameRepositoryWithData(db *sqlx.DB) (*GameRepository, error) {
-	repository, err := NewGameRepositoryFromDb(db)
-	if err != nil {
-		return nil, err
-	}
-
-	games := []domain.Game{
+func newInitialGames() []domain.Game {
+	return []domain.Game{
 		{Name: "GAME ONE", ID: 1, Players: map[string]domain.Player{}},
 		{Name: "GAME TWO", ID: 2, Players: map[string]domain.Player{"P1": {}, "P2": {}}},
 		newTeamingGame(),
 		newCompleteGame(),
 		{Name: "PREVIOUS GAME ONE", ID: 3, Players: map[string]domain.Player{}, Root: 1},
 	}
+}
+
+func NewGameRepositoryWithData(db *sqlx.DB) (*GameRepository, error) {
+	repository, err := NewGameRepositoryFromDb(db)
+	if err != nil {
+		return nil, err
+	}
 
 	tx := repository.db.MustBegin()
 
-	for _, game := range games {
+	for _, game := range newInitialGames() {
 		_, err := createGame(game, tx)
 		if err != nil {
 			return nil, err
@@ -43,7 +48,7 @@ func newTeamingGame() domain.Game {
 		Name:    "GAME TEAMING",
 		Players: map[string]domain.Player{},
 		Phase:   domain.Teaming,
-		Bids:    map[domain.BidValue]domain.Bid{},
+		Bids:    []domain.BidAction{},
 		Deck: []domain.CardID{
 			domain.C7, domain.C8, domain.C9, domain.C10, domain.CJ, domain.CQ, domain.CK, domain.CA,
 			domain.D7, domain.D8, domain.D9, domain.D10, domain.DJ, domain.DQ, domain.DK, domain.DA,
@@ -64,15 +69,14 @@ func newCompleteGame() domain.Game {
 		"P4": {Team: "even", Order: 4, InitialOrder: 4},
 	}
 	game.Phase = domain.Bidding
-	game.Bids = map[domain.BidValue]domain.Bid{
-		domain.Eighty: {
-			Player:  "P1",
-			Color:   domain.Heart,
-			Coinche: 0,
-			Pass:    0,
-		},
+	game.Rules = domain.ContreeFFBRules
+	game.Bids = []domain.BidAction{
+		{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Heart},
 	}
 	game.Deck = []domain.CardID{}
+	game.Declarations = []domain.Declaration{
+		{Player: "P1", Cards: []domain.CardID{domain.C7, domain.C8, domain.C9}},
+	}
 	game.Scores = map[string]int{
 		"odd":  72,
 		"even": 90,
@@ -134,8 +138,20 @@ func newCompleteGame() domain.Game {
 	return game
 }
 
+func newFileGameRepository(test *testing.T) *file.GameRepository {
+	repository, err := file.NewGameRepository(test.TempDir())
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Cleanup(func() {
+		repository.Close()
+	})
+
+	return repository
+}
+
 func TestGameRepo(test *testing.T) {
-	assert := assert.New(test)
 	dbName := "testgamerepodb"
 	utilities.LoadEnv("../.env")
 
@@ -146,6 +162,20 @@ func TestGameRepo(test *testing.T) {
 		test.Fatal(err)
 	}
 
+	testGameRepo(test, repository)
+
+	test.Cleanup(func() {
+		testUtilities.DropDb(postgres, dbName, db)
+	})
+}
+
+func TestFileGameRepo(test *testing.T) {
+	testGameRepo(test, newFileGameRepository(test))
+}
+
+func testGameRepo(test *testing.T, repository usecases.GameRepositoryInterface) {
+	assert := assert.New(test)
+
 	test.Run("create a simple game", func(test *testing.T) {
 		newName := "NEW GAME ONE"
 		newPlayers := map[string]domain.Player{"P1": {}, "P2": {}}
@@ -169,6 +199,8 @@ func TestGameRepo(test *testing.T) {
 
 	test.Run("create a teaming game", func(test *testing.T) {
 		newGame := newTeamingGame()
+		newGame.Seed = -42
+		newGame.Draws = 1
 
 		newID, err := repository.CreateGame(newGame)
 		if err != nil {
@@ -186,10 +218,13 @@ func TestGameRepo(test *testing.T) {
 		assert.IsType(time.Time{}, got.CreatedAt)
 		assert.Equal(newGame.Phase, got.Phase)
 		assert.Equal(newGame.Deck, got.Deck)
+		assert.Equal(newGame.Seed, got.Seed)
+		assert.Equal(newGame.Draws, got.Draws)
 	})
 
 	test.Run("create a complete game", func(test *testing.T) {
 		newGame := newCompleteGame()
+		newGame.Spectators = []string{"S1"}
 
 		newID, err := repository.CreateGame(newGame)
 		if err != nil {
@@ -209,17 +244,15 @@ func TestGameRepo(test *testing.T) {
 		assert.Equal(newGame.Deck, got.Deck)
 		assert.Equal(newGame.Bids, got.Bids)
 		assert.Equal(newGame.Turns, got.Turns)
+		assert.Equal(newGame.Declarations, got.Declarations)
+		assert.Equal(newGame.Rules, got.Rules)
+		assert.Equal(newGame.Spectators, got.Spectators)
 		assert.Equal(newGame.Points, got.Points)
 		assert.Equal(newGame.Scores, got.Scores)
 	})
-
-	test.Cleanup(func() {
-		testUtilities.DropDb(postgres, dbName, db)
-	})
 }
 
 func TestGameRepoWithInitialData(test *testing.T) {
-	assert := assert.New(test)
 	dbName := "testgamerepowithinitialdatadb"
 	db, postgres := testUtilities.CreateDb(dbName)
 
@@ -228,6 +261,29 @@ func TestGameRepoWithInitialData(test *testing.T) {
 		test.Fatal(err)
 	}
 
+	testGameRepoWithInitialData(test, repository)
+
+	test.Cleanup(func() {
+		testUtilities.DropDb(postgres, dbName, db)
+	})
+}
+
+func TestFileGameRepoWithInitialData(test *testing.T) {
+	repository := newFileGameRepository(test)
+
+	for _, game := range newInitialGames() {
+		_, err := repository.CreateGame(game)
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	testGameRepoWithInitialData(test, repository)
+}
+
+func testGameRepoWithInitialData(test *testing.T, repository usecases.GameRepositoryInterface) {
+	assert := assert.New(test)
+
 	test.Run("get an empty game", func(test *testing.T) {
 		want := domain.Game{Name: "GAME ONE", ID: 1, Players: map[string]domain.Player{}}
 
@@ -275,12 +331,17 @@ func TestGameRepoWithInitialData(test *testing.T) {
 	test.Run("update a player", func(test *testing.T) {
 		player := domain.Player{Team: "A Team"}
 
-		err := repository.UpdatePlayer(2, "P2", player)
+		game, err := repository.GetGame(2)
 		if err != nil {
 			test.Fatal(err)
 		}
 
-		game, err := repository.GetGame(2)
+		err = repository.UpdatePlayer(2, game.Version, "P2", player)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err = repository.GetGame(2)
 		if err != nil {
 			test.Fatal(err)
 		}
@@ -292,14 +353,15 @@ func TestGameRepoWithInitialData(test *testing.T) {
 		want := domain.Game{
 			ID:    2,
 			Phase: domain.Bidding,
-			Bids: map[domain.BidValue]domain.Bid{
-				domain.Eighty: {Player: "P1", Color: domain.Spade, Coinche: 1},
+			Bids: []domain.BidAction{
+				{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Spade},
+				{Sequence: 2, Kind: domain.CoincheAction, Player: "P2"},
 			},
 			Players: map[string]domain.Player{
 				"P1": {Hand: []domain.CardID{domain.C7}, Order: 1, InitialOrder: 1, Team: "A Team"},
 				"P2": {Hand: []domain.CardID{}},
 				"P3": {Hand: []domain.CardID{}},
-				"P4": {Hand: []domain.CardID{}},
+				"P4": {Hand: []domain.CardID{}, Bot: "heuristic"},
 			},
 			Turns: []domain.Turn{
 				{Plays: []domain.Play{
@@ -315,7 +377,16 @@ func TestGameRepoWithInitialData(test *testing.T) {
 				"A Team": 1000,
 				"B Team": 500,
 			},
-			Root: 2,
+			Declarations: []domain.Declaration{
+				{Player: "P1", Cards: []domain.CardID{domain.HJ, domain.HQ, domain.HK}},
+			},
+			Root:    2,
+			Redeals: 1,
+			Target:  1500,
+			Winner:  "A Team",
+			Version: 1,
+			Dealer:  "P4",
+			Draws:   3,
 		}
 
 		err := repository.UpdateGame(want)
@@ -338,11 +409,17 @@ func TestGameRepoWithInitialData(test *testing.T) {
 			}, Winner: "P2"},
 		}
 
-		want.Bids = map[domain.BidValue]domain.Bid{
-			domain.Eighty: {Player: "P1", Color: domain.Spade, Coinche: 2, Pass: 3},
+		want.Bids = []domain.BidAction{
+			{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Spade},
+			{Sequence: 2, Kind: domain.CoincheAction, Player: "P2"},
+			{Sequence: 3, Kind: domain.SurcoincheAction, Player: "P1"},
+			{Sequence: 4, Kind: domain.PassAction, Player: "P2"},
+			{Sequence: 5, Kind: domain.PassAction, Player: "P4"},
 		}
 
 		want.Root = 0
+		want.Version = 2
+		want.Declarations = append(want.Declarations, domain.Declaration{Player: "P2", Cards: []domain.CardID{domain.C10, domain.CJ, domain.CQ}})
 
 		err = repository.UpdateGame(want)
 		if err != nil {
@@ -364,16 +441,35 @@ func TestGameRepoWithInitialData(test *testing.T) {
 		assert.Equal(want.Turns[1], got.Turns[1])
 		assert.Equal(want.Points, got.Points)
 		assert.Equal(want.Scores, got.Scores)
+		assert.Equal(want.Declarations, got.Declarations)
 		assert.Equal(want.Root, got.Root)
+		assert.Equal(want.Redeals, got.Redeals)
+		assert.Equal(want.Target, got.Target)
+		assert.Equal(want.Winner, got.Winner)
+		assert.Equal(want.Dealer, got.Dealer)
+		assert.Equal(want.Draws, got.Draws)
+		assert.Equal(3, got.Version)
+	})
+
+	test.Run("refuse to update a game which has changed since it has been read", func(test *testing.T) {
+		err := repository.UpdateGame(domain.Game{ID: 2, Version: 1})
+
+		assert.EqualError(err, usecases.ErrVersionConflict)
+
+		err = repository.UpdatePlayer(2, 1, "P2", domain.Player{})
+
+		assert.EqualError(err, usecases.ErrVersionConflict)
 	})
 
 	test.Run("reset a game", func(test *testing.T) {
 		want := domain.Game{
-			ID:     2,
-			Phase:  domain.Bidding,
-			Bids:   map[domain.BidValue]domain.Bid{},
-			Turns:  []domain.Turn{},
-			Points: map[string]int{},
+			Version:      3,
+			ID:           2,
+			Phase:        domain.Bidding,
+			Bids:         []domain.BidAction{},
+			Turns:        []domain.Turn{},
+			Points:       map[string]int{},
+			Declarations: []domain.Declaration{},
 		}
 
 		err := repository.UpdateGame(want)
@@ -389,10 +485,62 @@ func TestGameRepoWithInitialData(test *testing.T) {
 		assert.Equal(0, len(got.Bids))
 		assert.Equal(0, len(got.Turns))
 		assert.Equal(0, len(got.Points))
+		assert.Equal(0, len(got.Declarations))
 		assert.Equal(0, len(got.Deck))
 	})
 
-	test.Cleanup(func() {
-		testUtilities.DropDb(postgres, dbName, db)
+	test.Run("log events in order", func(test *testing.T) {
+		game, err := repository.GetGame(4)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		err = repository.UpdateGame(game, domain.NewEvent(domain.BidEvent, "P1", domain.EventPayload{Value: domain.Ninety, Color: domain.Heart}))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		err = repository.UpdatePlayer(4, game.Version+1, "P2", game.Players["P2"], domain.NewEvent(domain.PassEvent, "P2", domain.EventPayload{}))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := repository.GetEvents(4)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(2, len(got))
+		assert.Equal(1, got[0].Sequence)
+		assert.Equal(domain.BidEvent, got[0].Kind)
+		assert.Equal("P1", got[0].Player)
+		assert.Equal(domain.EventPayload{Value: domain.Ninety, Color: domain.Heart}, got[0].Payload)
+		assert.Equal(2, got[1].Sequence)
+		assert.Equal(domain.PassEvent, got[1].Kind)
+	})
+
+	test.Run("keep a bounded chat history", func(test *testing.T) {
+		for i := 0; i < domain.CHAT_HISTORY_SIZE+2; i++ {
+			message, err := domain.NewChatMessage("P1", fmt.Sprint("message ", i))
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			err = repository.AddChatMessage(4, message)
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		got, err := repository.GetChatMessages(4)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(domain.CHAT_HISTORY_SIZE, len(got))
+		assert.Equal("message 2", got[0].Content)
+		assert.Equal("P1", got[0].Sender)
+		assert.Equal(domain.TextChat, got[0].Kind)
+		assert.IsType(time.Time{}, got[0].CreatedAt)
 	})
 }
@@ -12,14 +12,32 @@ import (
 func getGame(tx *sqlx.Tx, gameID int) (domain.Game, error) {
 	var game domain.Game
 	var deck []byte
+	var rules []byte
+	var spectators []byte
 
-	err := tx.QueryRow(`SELECT * FROM game WHERE id=$1`, gameID).Scan(
+	err := tx.QueryRow(
+		`
+		SELECT id, name, createdAt, phase, deck, root, redeals, target, winner, rules, spectators, version, dealer, seed, draws
+		FROM game
+		WHERE id=$1
+		`,
+		gameID,
+	).Scan(
 		&game.ID,
 		&game.Name,
 		&game.CreatedAt,
 		&game.Phase,
 		&deck,
 		&game.Root,
+		&game.Redeals,
+		&game.Target,
+		&game.Winner,
+		&rules,
+		&spectators,
+		&game.Version,
+		&game.Dealer,
+		&game.Seed,
+		&game.Draws,
 	)
 
 	if err != nil {
@@ -31,6 +49,16 @@ func getGame(tx *sqlx.Tx, gameID int) (domain.Game, error) {
 		return domain.Game{}, errors.New(fmt.Sprint(err, "Deck: ", deck))
 	}
 
+	err = json.Unmarshal(rules, &game.Rules)
+	if err != nil {
+		return domain.Game{}, errors.New(fmt.Sprint(err, "Rules: ", rules))
+	}
+
+	err = json.Unmarshal(spectators, &game.Spectators)
+	if err != nil {
+		return domain.Game{}, errors.New(fmt.Sprint(err, "Spectators: ", spectators))
+	}
+
 	game.Players, err = getPlayers(tx, gameID)
 	if err != nil {
 		return domain.Game{}, err
@@ -46,6 +74,11 @@ func getGame(tx *sqlx.Tx, gameID int) (domain.Game, error) {
 		return domain.Game{}, err
 	}
 
+	game.Declarations, err = getDeclarations(tx, gameID)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
 	game.Scores, err = getScoresOrPoints(tx, gameID, "score")
 	if err != nil {
 		return domain.Game{}, err
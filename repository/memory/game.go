@@ -0,0 +1,285 @@
+package memory
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GameRepository keeps the games in memory, they are lost when the server stops. It stores copies of the games, so the
+// callers can mutate the games they read or write without changing the stored ones.
+type GameRepository struct {
+	usecases.GameRepositoryInterface
+	mu     sync.Mutex
+	lastID int
+	games  map[int]domain.Game
+	events map[int][]domain.Event
+	chats  map[int][]domain.ChatMessage
+}
+
+func NewGameRepository() *GameRepository {
+	return &GameRepository{
+		games:  make(map[int]domain.Game),
+		events: make(map[int][]domain.Event),
+		chats:  make(map[int][]domain.ChatMessage),
+	}
+}
+
+func CopyGame(game domain.Game) (domain.Game, error) {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	var copied domain.Game
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
+
+func (r *GameRepository) getGame(gameID int) (domain.Game, error) {
+	game, ok := r.games[gameID]
+	if !ok {
+		return domain.Game{}, errors.New(usecases.ErrGameNotFound)
+	}
+	return CopyGame(game)
+}
+
+func (r *GameRepository) GetGame(gameID int) (domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.getGame(gameID)
+}
+
+// ListGames only gives the current deal of each game, the archived deals having another game as root.
+func (r *GameRepository) ListGames() ([]domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	gameIDs := []int{}
+	for gameID, game := range r.games {
+		if game.Root == gameID {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	sort.Ints(gameIDs)
+
+	games := []domain.Game{}
+	for _, gameID := range gameIDs {
+		game, err := r.getGame(gameID)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+func (r *GameRepository) CreateGame(game domain.Game, events ...domain.Event) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created, err := CopyGame(game)
+	if err != nil {
+		return 0, err
+	}
+
+	r.lastID++
+	created.ID = r.lastID
+	created.CreatedAt = time.Now()
+	created.Version = 0
+	if created.Root == 0 {
+		created.Root = created.ID
+	}
+
+	r.games[created.ID] = created
+	r.appendEvents(created.ID, events)
+
+	return created.ID, nil
+}
+
+// MergePlayerUpdate gives the game to store after the update of one of its players, an unknown player being ignored.
+func MergePlayerUpdate(stored domain.Game, version int, playerName string, player domain.Player) (domain.Game, error) {
+	if stored.Version != version {
+		return domain.Game{}, errors.New(usecases.ErrVersionConflict)
+	}
+
+	updated, err := CopyGame(stored)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if _, ok := updated.Players[playerName]; ok {
+		updated.Players[playerName] = player
+	}
+	updated.Version++
+
+	return CopyGame(updated)
+}
+
+func (r *GameRepository) UpdatePlayer(gameID int, version int, playerName string, player domain.Player, events ...domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.games[gameID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	updated, err := MergePlayerUpdate(stored, version, playerName, player)
+	if err != nil {
+		return err
+	}
+
+	r.games[gameID] = updated
+	r.appendEvents(gameID, events)
+
+	return nil
+}
+
+// MergeUpdate gives the game to store after an update, like the Postgres repository does: a nil collection leaves the
+// stored one untouched, while an empty one resets it.
+func MergeUpdate(stored domain.Game, game domain.Game) (domain.Game, error) {
+	if stored.Version != game.Version {
+		return domain.Game{}, errors.New(usecases.ErrVersionConflict)
+	}
+
+	updated, err := CopyGame(game)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	updated.Name = stored.Name
+	updated.CreatedAt = stored.CreatedAt
+	updated.Version = stored.Version + 1
+	if game.Bids == nil {
+		updated.Bids = stored.Bids
+	}
+	if game.Turns == nil {
+		updated.Turns = stored.Turns
+	}
+	if game.Declarations == nil {
+		updated.Declarations = stored.Declarations
+	}
+	if game.Points == nil {
+		updated.Points = stored.Points
+	}
+	if game.Scores == nil {
+		updated.Scores = stored.Scores
+	}
+
+	return updated, nil
+}
+
+func (r *GameRepository) UpdateGame(game domain.Game, events ...domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.games[game.ID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	updated, err := MergeUpdate(stored, game)
+	if err != nil {
+		return err
+	}
+
+	r.games[game.ID] = updated
+	r.appendEvents(game.ID, events)
+
+	return nil
+}
+
+func (r *GameRepository) ArchiveAndUpdateGame(game domain.Game, events ...domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.games[game.ID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	updated, err := MergeUpdate(stored, game)
+	if err != nil {
+		return err
+	}
+
+	archive, err := CopyGame(stored)
+	if err != nil {
+		return err
+	}
+
+	r.lastID++
+	archive.ID = r.lastID
+	archive.CreatedAt = time.Now()
+	archive.Version = 0
+
+	r.games[archive.ID] = archive
+	r.games[game.ID] = updated
+	r.appendEvents(game.ID, events)
+
+	return nil
+}
+
+func (r *GameRepository) DeleteGame(gameID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	game, ok := r.games[gameID]
+	if !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	if len(game.Players) > 0 {
+		return errors.New(usecases.ErrCannotDeleteWithPlayers)
+	}
+
+	delete(r.games, gameID)
+	delete(r.events, gameID)
+	delete(r.chats, gameID)
+
+	return nil
+}
+
+func (r *GameRepository) appendEvents(gameID int, events []domain.Event) {
+	for _, event := range events {
+		event.Sequence = len(r.events[gameID]) + 1
+		r.events[gameID] = append(r.events[gameID], event)
+	}
+}
+
+func (r *GameRepository) GetEvents(gameID int) ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.Event{}, r.events[gameID]...), nil
+}
+
+// AddChatMessage also forgets the messages beyond the history size, so the history stays bounded for each game.
+func (r *GameRepository) AddChatMessage(gameID int, message domain.ChatMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.games[gameID]; !ok {
+		return errors.New(usecases.ErrGameNotFound)
+	}
+
+	message.CreatedAt = time.Now()
+	messages := append(r.chats[gameID], message)
+	if len(messages) > domain.CHAT_HISTORY_SIZE {
+		messages = messages[len(messages)-domain.CHAT_HISTORY_SIZE:]
+	}
+	r.chats[gameID] = messages
+
+	return nil
+}
+
+func (r *GameRepository) GetChatMessages(gameID int) ([]domain.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.ChatMessage{}, r.chats[gameID]...), nil
+}
@@ -0,0 +1,256 @@
+package memory
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameRepo(test *testing.T) {
+	assert := assert.New(test)
+
+	gameRepository := NewGameRepository()
+
+	test.Run("create and get a game", func(test *testing.T) {
+		game := domain.NewGame("GAME ONE")
+		game.Players["P1"] = domain.Player{Team: "A Team"}
+
+		gameID, err := gameRepository.CreateGame(game, domain.NewEvent(domain.CreateEvent, "", domain.EventPayload{Name: "GAME ONE"}))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := gameRepository.GetGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(1, got.ID)
+		assert.Equal(1, got.Root)
+		assert.Equal("GAME ONE", got.Name)
+		assert.Equal(game.Players, got.Players)
+		assert.Equal(game.Deck, got.Deck)
+		assert.False(got.CreatedAt.IsZero())
+	})
+
+	test.Run("not share the stored game", func(test *testing.T) {
+		game, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game.Players["P2"] = domain.Player{}
+
+		got, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(1, len(got.Players))
+	})
+
+	test.Run("not get an unknown game", func(test *testing.T) {
+		_, err := gameRepository.GetGame(42)
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrGameNotFound, err.Error())
+	})
+
+	test.Run("list the games but not the archived deals", func(test *testing.T) {
+		archive, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		_, err = gameRepository.CreateGame(archive)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		_, err = gameRepository.CreateGame(domain.NewGame("GAME THREE"))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := gameRepository.ListGames()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(2, len(got))
+		assert.Equal(1, got[0].ID)
+		assert.Equal(3, got[1].ID)
+	})
+
+	test.Run("update a game", func(test *testing.T) {
+		game, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game.Phase = domain.Bidding
+		game.Bids = []domain.BidAction{
+			{Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Heart},
+		}
+		game.Turns = nil
+
+		err = gameRepository.UpdateGame(game, domain.NewEvent(domain.BidEvent, "P1", domain.EventPayload{Value: domain.Eighty}))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(domain.Bidding, got.Phase)
+		assert.Equal(game.Bids, got.Bids)
+		assert.Equal(1, got.Version)
+	})
+
+	test.Run("keep a collection when it is not given", func(test *testing.T) {
+		game, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game.Bids = nil
+
+		err = gameRepository.UpdateGame(game)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(1, len(got.Bids))
+	})
+
+	test.Run("refuse to update a game which has changed since it has been read", func(test *testing.T) {
+		err := gameRepository.UpdateGame(domain.Game{ID: 1, Version: 0})
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrVersionConflict, err.Error())
+
+		err = gameRepository.UpdatePlayer(1, 0, "P1", domain.Player{})
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrVersionConflict, err.Error())
+	})
+
+	test.Run("archive a deal only with the update of its game", func(test *testing.T) {
+		lastID := gameRepository.lastID
+
+		err := gameRepository.ArchiveAndUpdateGame(domain.Game{ID: 1, Version: 0})
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrVersionConflict, err.Error())
+		assert.Equal(lastID, gameRepository.lastID)
+	})
+
+	test.Run("update a player", func(test *testing.T) {
+		err := gameRepository.UpdatePlayer(1, 2, "P1", domain.Player{Team: "B Team"}, domain.NewEvent(domain.JoinTeamEvent, "P1", domain.EventPayload{Team: "B Team"}))
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := gameRepository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("B Team", got.Players["P1"].Team)
+		assert.Equal(3, got.Version)
+	})
+
+	test.Run("log events in order", func(test *testing.T) {
+		got, err := gameRepository.GetEvents(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(3, len(got))
+		assert.Equal(domain.CreateEvent, got[0].Kind)
+		assert.Equal(domain.BidEvent, got[1].Kind)
+		assert.Equal(domain.JoinTeamEvent, got[2].Kind)
+		assert.Equal(3, got[2].Sequence)
+	})
+
+	test.Run("keep a bounded chat history", func(test *testing.T) {
+		for i := 0; i < domain.CHAT_HISTORY_SIZE+2; i++ {
+			message, err := domain.NewChatMessage("P1", fmt.Sprint("message ", i))
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			err = gameRepository.AddChatMessage(1, message)
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		got, err := gameRepository.GetChatMessages(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(domain.CHAT_HISTORY_SIZE, len(got))
+		assert.Equal("message 2", got[0].Content)
+	})
+
+	test.Run("not delete a game with players", func(test *testing.T) {
+		err := gameRepository.DeleteGame(1)
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrCannotDeleteWithPlayers, err.Error())
+	})
+
+	test.Run("delete a game", func(test *testing.T) {
+		err := gameRepository.DeleteGame(3)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		_, err = gameRepository.GetGame(3)
+
+		assert.Error(err)
+	})
+}
+
+func TestConcurrentCommands(test *testing.T) {
+	assert := assert.New(test)
+
+	gameRepository := NewGameRepository()
+	gameUsecases := usecases.NewGameUsecases(gameRepository)
+
+	gameID, err := gameUsecases.CreateGame("GAME ONE", 0, "", "", 0)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 4; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, err := gameUsecases.JoinGame(gameID, name)
+			assert.NoError(err)
+		}(fmt.Sprint("P", i))
+	}
+	wg.Wait()
+
+	game, err := gameRepository.GetGame(gameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	assert.Equal(4, len(game.Players))
+}
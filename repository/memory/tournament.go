@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TournamentRepository keeps copies of the tournaments in memory, like the games.
+type TournamentRepository struct {
+	usecases.TournamentRepositoryInterface
+	mu          sync.Mutex
+	lastID      int
+	tournaments map[int]domain.Tournament
+}
+
+func NewTournamentRepository() *TournamentRepository {
+	return &TournamentRepository{tournaments: make(map[int]domain.Tournament)}
+}
+
+func CopyTournament(tournament domain.Tournament) (domain.Tournament, error) {
+	data, err := json.Marshal(tournament)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	var copied domain.Tournament
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
+
+func (r *TournamentRepository) ListTournaments() ([]domain.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournamentIDs := []int{}
+	for tournamentID := range r.tournaments {
+		tournamentIDs = append(tournamentIDs, tournamentID)
+	}
+	sort.Ints(tournamentIDs)
+
+	tournaments := []domain.Tournament{}
+	for _, tournamentID := range tournamentIDs {
+		tournament, err := CopyTournament(r.tournaments[tournamentID])
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+
+	return tournaments, nil
+}
+
+func (r *TournamentRepository) CreateTournament(tournament domain.Tournament) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created, err := CopyTournament(tournament)
+	if err != nil {
+		return 0, err
+	}
+
+	r.lastID++
+	created.ID = r.lastID
+	created.CreatedAt = time.Now()
+	r.tournaments[created.ID] = created
+
+	return created.ID, nil
+}
+
+func (r *TournamentRepository) GetTournament(tournamentID int) (domain.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournament, ok := r.tournaments[tournamentID]
+	if !ok {
+		return domain.Tournament{}, errors.New(usecases.ErrTournamentNotFound)
+	}
+
+	return CopyTournament(tournament)
+}
+
+func (r *TournamentRepository) UpdateTournament(tournament domain.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tournaments[tournament.ID]
+	if !ok {
+		return errors.New(usecases.ErrTournamentNotFound)
+	}
+
+	updated, err := CopyTournament(tournament)
+	if err != nil {
+		return err
+	}
+
+	updated.CreatedAt = stored.CreatedAt
+	r.tournaments[tournament.ID] = updated
+
+	return nil
+}
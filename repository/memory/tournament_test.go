@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournamentRepo(test *testing.T) {
+	assert := assert.New(test)
+
+	tournamentRepository := NewTournamentRepository()
+	tournamentID := 0
+
+	test.Run("create and get a tournament", func(test *testing.T) {
+		tournament, _ := domain.NewTournament("CUP", "", "", 0, 42)
+
+		var err error
+		tournamentID, err = tournamentRepository.CreateTournament(tournament)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := tournamentRepository.GetTournament(tournamentID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(tournamentID, got.ID)
+		assert.Equal("CUP", got.Name)
+		assert.Equal(int64(42), got.Seed)
+		assert.False(got.CreatedAt.IsZero())
+	})
+
+	test.Run("update a tournament without sharing it with the caller", func(test *testing.T) {
+		tournament, _ := tournamentRepository.GetTournament(tournamentID)
+		_ = tournament.AddPair("A", []string{"A1", "A2"})
+
+		err := tournamentRepository.UpdateTournament(tournament)
+		assert.NoError(err)
+
+		tournament.Pairs[0].Players[0] = "B1"
+		got, _ := tournamentRepository.GetTournament(tournamentID)
+
+		assert.Equal([]domain.Pair{{Name: "A", Players: []string{"A1", "A2"}}}, got.Pairs)
+	})
+
+	test.Run("list the tournaments by id", func(test *testing.T) {
+		tournament, _ := domain.NewTournament("OTHER CUP", domain.KnockoutFormat, "", 1500, 42)
+		_, _ = tournamentRepository.CreateTournament(tournament)
+
+		got, err := tournamentRepository.ListTournaments()
+
+		assert.NoError(err)
+		assert.Equal(2, len(got))
+		assert.Equal("CUP", got[0].Name)
+		assert.Equal(domain.KnockoutFormat, got[1].Format)
+		assert.Equal(1500, got[1].Target)
+	})
+
+	test.Run("not get an unknown tournament", func(test *testing.T) {
+		_, err := tournamentRepository.GetTournament(1000)
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrTournamentNotFound, err.Error())
+	})
+}
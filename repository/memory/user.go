@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"errors"
+	"sync"
+	"time"
+)
+
+type UserRepository struct {
+	usecases.UserRepositoryInterface
+	mu     sync.Mutex
+	lastID int
+	users  map[string]domain.User
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: make(map[string]domain.User)}
+}
+
+func (r *UserRepository) CreateUser(user domain.User) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.Name]; ok {
+		return 0, errors.New(domain.ErrUserAlreadyExists)
+	}
+
+	r.lastID++
+	user.ID = r.lastID
+	user.CreatedAt = time.Now()
+	r.users[user.Name] = user
+
+	return user.ID, nil
+}
+
+func (r *UserRepository) GetUser(name string) (domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[name]
+	if !ok {
+		return domain.User{}, errors.New(domain.ErrUserNotFound)
+	}
+
+	return user, nil
+}
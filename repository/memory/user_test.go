@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"coinche/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepo(test *testing.T) {
+	assert := assert.New(test)
+
+	userRepository := NewUserRepository()
+
+	test.Run("create and get a user", func(test *testing.T) {
+		newID, err := userRepository.CreateUser(domain.User{Name: "P1", PasswordHash: "HASH"})
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := userRepository.GetUser("P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(newID, got.ID)
+		assert.Equal("P1", got.Name)
+		assert.Equal("HASH", got.PasswordHash)
+		assert.False(got.CreatedAt.IsZero())
+	})
+
+	test.Run("not create a user twice", func(test *testing.T) {
+		_, err := userRepository.CreateUser(domain.User{Name: "P1", PasswordHash: "OTHER HASH"})
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUserAlreadyExists, err.Error())
+	})
+
+	test.Run("not get an unknown user", func(test *testing.T) {
+		_, err := userRepository.GetUser("P2")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUserNotFound, err.Error())
+	})
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const ErrInvalidMigrationName = "INVALID MIGRATION NAME"
+
+// MIGRATION_LOCK is the key of the advisory lock taken while migrating, so servers starting together migrate once.
+const MIGRATION_LOCK = 20211018
+
+var migrationSchema = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version integer PRIMARY KEY NOT NULL,
+	name text NOT NULL,
+	appliedAt timestamp NOT NULL DEFAULT now()
+)`
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is a file of the migrations directory, named after its version and what it does, e.g. 0002_add_redeals.sql.
+// Migrations are applied in the order of their versions and never changed once released.
+type Migration struct {
+	Version    int
+	Name       string
+	statements string
+}
+
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+func parseMigrationName(fileName string) (int, string, error) {
+	parts := strings.SplitN(strings.TrimSuffix(fileName, ".sql"), "_", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", errors.New(fmt.Sprint(ErrInvalidMigrationName, " ", fileName))
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil || version <= 0 {
+		return 0, "", errors.New(fmt.Sprint(ErrInvalidMigrationName, " ", fileName))
+	}
+
+	return version, parts[1], nil
+}
+
+func GetMigrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []Migration{}
+	for _, entry := range entries {
+		version, name, err := parseMigrationName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(migrations) > 0 && migrations[len(migrations)-1].Version >= version {
+			return nil, errors.New(fmt.Sprint(ErrInvalidMigrationName, " ", entry.Name()))
+		}
+
+		statements, err := migrationFiles.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: name, statements: string(statements)})
+	}
+
+	return migrations, nil
+}
+
+func getAppliedMigrations(tx *sqlx.Tx) (map[int]time.Time, error) {
+	rows, err := tx.Query(`SELECT version, appliedAt FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		err := rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// applyMigration runs the file at once, without arguments so it is sent as a simple query which may hold several
+// statements, a semicolon within a statement being left as it is.
+func applyMigration(tx *sqlx.Tx, migration Migration) error {
+	_, err := tx.Exec(migration.statements)
+	if err != nil {
+		return errors.New(fmt.Sprint("Migration ", migration.Version, " ", migration.Name, ": ", err))
+	}
+
+	_, err = tx.Exec(
+		`
+		INSERT INTO schema_migrations (version, name)
+		VALUES ($1, $2)
+		`,
+		migration.Version,
+		migration.Name,
+	)
+	return err
+}
+
+func migrate(tx *sqlx.Tx) ([]Migration, error) {
+	_, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, MIGRATION_LOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(migrationSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := GetMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := getAppliedMigrations(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	newlyApplied := []Migration{}
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := applyMigration(tx, migration)
+		if err != nil {
+			return nil, err
+		}
+		newlyApplied = append(newlyApplied, migration)
+	}
+
+	return newlyApplied, nil
+}
+
+// Migrate applies the migrations which have not been applied yet, all of them or none as they share a transaction.
+// It gives the migrations it has applied.
+func Migrate(db *sqlx.DB) ([]Migration, error) {
+	tx := db.MustBegin()
+
+	migrations, err := migrate(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return migrations, tx.Commit()
+}
+
+func GetMigrationStatuses(db *sqlx.DB) ([]MigrationStatus, error) {
+	migrations, err := GetMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	var table *string
+	err = tx.QueryRow(`SELECT to_regclass('schema_migrations')::text`).Scan(&table)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time)
+	if table != nil {
+		applied, err = getAppliedMigrations(tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, MigrationStatus{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+	}
+
+	return statuses, nil
+}
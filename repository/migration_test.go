@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"coinche/utilities"
+	testUtilities "coinche/utilities/test"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMigrations(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("give the migrations in order", func(test *testing.T) {
+		got, err := GetMigrations()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Less(0, len(got))
+		for i, migration := range got {
+			assert.Equal(i+1, migration.Version)
+			assert.NotEmpty(migration.statements)
+		}
+		assert.Equal("create_games", got[0].Name)
+	})
+
+	test.Run("refuse a file without version", func(test *testing.T) {
+		_, _, err := parseMigrationName("add_redeals.sql")
+
+		assert.Error(err)
+	})
+
+	test.Run("parse a migration name", func(test *testing.T) {
+		version, name, err := parseMigrationName("0002_add_redeals.sql")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(2, version)
+		assert.Equal("add_redeals", name)
+	})
+}
+
+func TestMigrate(test *testing.T) {
+	assert := assert.New(test)
+	dbName := "testmigratedb"
+	utilities.LoadEnv("../.env")
+
+	db, postgres := testUtilities.CreateDb(dbName)
+
+	migrations, err := GetMigrations()
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("show every migration as pending on a new database", func(test *testing.T) {
+		got, err := GetMigrationStatuses(db)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(len(migrations), len(got))
+		assert.False(got[0].Applied)
+	})
+
+	test.Run("upgrade a database created before the migrations", func(test *testing.T) {
+		_, err := db.Exec(`
+		CREATE TABLE game (
+			id serial PRIMARY KEY NOT NULL,
+			name text NOT NULL,
+			createdAt timestamp NOT NULL DEFAULT now(),
+			phase integer DEFAULT 0,
+			deck json NOT NULL DEFAULT '[]',
+			root integer
+		)`)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		_, err = db.Exec(`INSERT INTO game (name, root) VALUES ('OLD GAME', 1)`)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := Migrate(db)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(len(migrations), len(got))
+
+		repository := GameRepository{db: db}
+		game, err := repository.GetGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal("OLD GAME", game.Name)
+		assert.Equal(0, game.Version)
+	})
+
+	test.Run("apply a migration only once", func(test *testing.T) {
+		got, err := Migrate(db)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(0, len(got))
+
+		statuses, err := GetMigrationStatuses(db)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		for _, status := range statuses {
+			assert.True(status.Applied)
+			assert.False(status.AppliedAt.IsZero())
+		}
+	})
+
+	test.Cleanup(func() {
+		testUtilities.DropDb(postgres, dbName, db)
+	})
+}
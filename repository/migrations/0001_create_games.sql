@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS game (
+	id serial PRIMARY KEY NOT NULL,
+	name text NOT NULL,
+	createdAt timestamp NOT NULL DEFAULT now(),
+	phase integer DEFAULT 0,
+	deck json NOT NULL DEFAULT '[]',
+	root integer
+);
+
+CREATE TABLE IF NOT EXISTS player (
+	id serial PRIMARY KEY NOT NULL,
+	name text NOT NULL,
+	team text,
+	gameid integer NOT NULL REFERENCES game(id),
+	createdAt timestamp NOT NULL DEFAULT now(),
+	initialOrder integer DEFAULT 0,
+	cOrder integer DEFAULT 0,
+	hand json NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE IF NOT EXISTS bid (
+	id serial PRIMARY KEY NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	value integer NOT NULL,
+	player text NOT NULL,
+	color text NOT NULL,
+	coinche integer DEFAULT 0,
+	pass integer DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS turn (
+	id serial PRIMARY KEY NOT NULL,
+	position integer NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	winner text NOT NULL,
+	plays json NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE IF NOT EXISTS point (
+	id serial PRIMARY KEY NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	team text NOT NULL,
+	value integer NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS score (
+	id serial PRIMARY KEY NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	team text NOT NULL,
+	value integer NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS redeals integer DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS target integer DEFAULT 0;
+ALTER TABLE game ADD COLUMN IF NOT EXISTS winner text NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS declaration (
+	id serial PRIMARY KEY NOT NULL,
+	position integer NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	player text NOT NULL,
+	cards json NOT NULL DEFAULT '[]'
+);
@@ -0,0 +1 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS rules json NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE player ADD COLUMN IF NOT EXISTS bot text NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS event (
+	id serial PRIMARY KEY NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	sequence integer NOT NULL,
+	player text NOT NULL DEFAULT '',
+	kind text NOT NULL,
+	payload json NOT NULL DEFAULT '{}',
+	createdAt timestamp NOT NULL DEFAULT now(),
+	UNIQUE (gameid, sequence)
+);
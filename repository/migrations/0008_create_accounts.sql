@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS account (
+	id serial PRIMARY KEY NOT NULL,
+	name text NOT NULL UNIQUE,
+	passwordHash text NOT NULL,
+	createdAt timestamp NOT NULL DEFAULT now()
+);
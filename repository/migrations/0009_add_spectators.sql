@@ -0,0 +1 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS spectators json NOT NULL DEFAULT '[]';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS chat (
+	id serial PRIMARY KEY NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	sender text NOT NULL,
+	kind text NOT NULL,
+	content text NOT NULL,
+	createdAt timestamp NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 0;
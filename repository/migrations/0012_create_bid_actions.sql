@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS bid_action (
+	id serial PRIMARY KEY NOT NULL,
+	gameid integer NOT NULL REFERENCES game(id),
+	sequence integer NOT NULL,
+	kind text NOT NULL,
+	player text NOT NULL DEFAULT '',
+	value integer NOT NULL DEFAULT 0,
+	color text NOT NULL DEFAULT '',
+	UNIQUE (gameid, sequence)
+);
+
+-- The bids were placed in the order of their values.
+INSERT INTO bid_action (gameid, sequence, kind, player, value, color)
+SELECT gameid, row_number() OVER (PARTITION BY gameid ORDER BY value), 'bid', player, value, color
+FROM bid
+WHERE value > 0;
+
+-- Only the highest bid counted its coinches and the passes following them, without who placed them.
+INSERT INTO bid_action (gameid, sequence, kind)
+SELECT bid.gameid,
+	(SELECT COUNT(*) FROM bid_action WHERE bid_action.gameid = bid.gameid) + n,
+	CASE WHEN n = 1 THEN 'coinche' ELSE 'surcoinche' END
+FROM bid, generate_series(1, bid.coinche) AS n
+WHERE bid.value = (SELECT MAX(value) FROM bid AS highest WHERE highest.gameid = bid.gameid);
+
+INSERT INTO bid_action (gameid, sequence, kind)
+SELECT bid.gameid, (SELECT COUNT(*) FROM bid_action WHERE bid_action.gameid = bid.gameid) + n, 'pass'
+FROM bid, generate_series(1, bid.pass) AS n
+WHERE bid.value = (SELECT MAX(value) FROM bid AS highest WHERE highest.gameid = bid.gameid);
+
+DROP TABLE bid;
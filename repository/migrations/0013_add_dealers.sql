@@ -0,0 +1 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS dealer text NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE game ADD COLUMN IF NOT EXISTS seed bigint NOT NULL DEFAULT 0;
+ALTER TABLE game ADD COLUMN IF NOT EXISTS draws integer NOT NULL DEFAULT 0;
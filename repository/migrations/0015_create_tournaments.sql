@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS tournament (
+	id serial PRIMARY KEY NOT NULL,
+	name text NOT NULL,
+	createdAt timestamp NOT NULL DEFAULT now(),
+	seed bigint NOT NULL DEFAULT 0,
+	rules text NOT NULL DEFAULT '',
+	pairs json NOT NULL DEFAULT '[]',
+	tables json NOT NULL DEFAULT '[]'
+);
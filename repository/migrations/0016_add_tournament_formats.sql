@@ -0,0 +1,2 @@
+ALTER TABLE tournament ADD COLUMN IF NOT EXISTS format text NOT NULL DEFAULT 'duplicate';
+ALTER TABLE tournament ADD COLUMN IF NOT EXISTS target integer NOT NULL DEFAULT 0;
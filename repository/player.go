@@ -7,18 +7,6 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-var playerSchema = `
-CREATE TABLE IF NOT EXISTS player (
-	id serial PRIMARY KEY NOT NULL,
-	name text NOT NULL,
-	team text,
-	gameid integer NOT NULL REFERENCES game(id),
-	createdAt timestamp NOT NULL DEFAULT now(),
-	initialOrder integer DEFAULT 0,
-	cOrder integer DEFAULT 0,
-	hand json NOT NULL DEFAULT '[]'
-)`
-
 func updatePlayer(tx *sqlx.Tx, gameID int, playerName string, player domain.Player) error {
 	hand, err := json.Marshal(player.Hand)
 	if err != nil {
@@ -28,7 +16,7 @@ func updatePlayer(tx *sqlx.Tx, gameID int, playerName string, player domain.Play
 	_, err = tx.Exec(
 		`
     UPDATE player
-    SET gameid =$1, name = $2, team = $3, initialOrder = $4, cOrder = $5, hand = $6
+    SET gameid =$1, name = $2, team = $3, initialOrder = $4, cOrder = $5, hand = $6, bot = $7
     WHERE gameid = $1 AND name = $2
     `,
 		gameID,
@@ -37,6 +25,7 @@ func updatePlayer(tx *sqlx.Tx, gameID int, playerName string, player domain.Play
 		player.InitialOrder,
 		player.Order,
 		hand,
+		player.Bot,
 	)
 	if err != nil {
 		return err
@@ -45,10 +34,41 @@ func updatePlayer(tx *sqlx.Tx, gameID int, playerName string, player domain.Play
 	return err
 }
 
-func (s *GameRepository) UpdatePlayer(gameID int, playerName string, player domain.Player) error {
+func bumpVersion(tx *sqlx.Tx, gameID int, version int) error {
+	result, err := tx.Exec(`UPDATE game SET version = version + 1 WHERE id = $1 AND version = $2`, gameID, version)
+	if err != nil {
+		return err
+	}
+	return checkUpdated(result)
+}
+
+func updateVersionedPlayer(
+	tx *sqlx.Tx,
+	gameID int,
+	version int,
+	playerName string,
+	player domain.Player,
+	events []domain.Event,
+) error {
+	err := bumpVersion(tx, gameID, version)
+	if err != nil {
+		return err
+	}
+
+	err = updatePlayer(tx, gameID, playerName, player)
+	if err != nil {
+		return err
+	}
+
+	return createEvents(tx, gameID, events)
+}
+
+func (s *GameRepository) UpdatePlayer(gameID int, version int, playerName string, player domain.Player, events ...domain.Event) error {
 	tx := s.db.MustBegin()
-	err := updatePlayer(tx, gameID, playerName, player)
+
+	err := updateVersionedPlayer(tx, gameID, version, playerName, player, events)
 	if err != nil {
+		_ = tx.Rollback()
 		return err
 	}
 
@@ -98,11 +118,12 @@ func deletePlayers(currentPlayers map[string]int, players map[string]domain.Play
 	return nil
 }
 
-func createPlayer(tx *sqlx.Tx, gameID int, playerName string, team string) error {
-	_, err := tx.Exec(`INSERT INTO player (gameid, name, team) VALUES ($1, $2, $3)`,
+func createPlayer(tx *sqlx.Tx, gameID int, playerName string, team string, bot string) error {
+	_, err := tx.Exec(`INSERT INTO player (gameid, name, team, bot) VALUES ($1, $2, $3, $4)`,
 		gameID,
 		playerName,
 		team,
+		bot,
 	)
 	return err
 }
@@ -115,7 +136,7 @@ func createAndUpdatePlayers(currentPlayers map[string]int, players map[string]do
 		}
 
 		if shouldCreate {
-			err := createPlayer(tx, gameID, playerName, player.Team)
+			err := createPlayer(tx, gameID, playerName, player.Team, player.Bot)
 			if err != nil {
 				return err
 			}
@@ -156,8 +177,8 @@ func createFullPlayerForTest(tx *sqlx.Tx, gameID int, name string, player domain
 
 	_, err = tx.Exec(
 		`
-			INSERT INTO player (name, team, gameid, initialOrder, cOrder, hand) 
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO player (name, team, gameid, initialOrder, cOrder, hand, bot) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 			`,
 		name,
 		player.Team,
@@ -165,6 +186,7 @@ func createFullPlayerForTest(tx *sqlx.Tx, gameID int, name string, player domain
 		player.InitialOrder,
 		player.Order,
 		hand,
+		player.Bot,
 	)
 
 	return err
@@ -177,12 +199,13 @@ func getPlayers(tx *sqlx.Tx, gameID int) (map[string]domain.Player, error) {
 		InitialOrder int
 		COrder       int
 		Hand         []byte
+		Bot          string
 	}
 
 	var dbPlayers []DBplayer
 	var players map[string]domain.Player = map[string]domain.Player{}
 
-	err := tx.Select(&dbPlayers, `SELECT name, team, initialOrder, cOrder, hand FROM player WHERE gameid=$1`, gameID)
+	err := tx.Select(&dbPlayers, `SELECT name, team, initialOrder, cOrder, hand, bot FROM player WHERE gameid=$1`, gameID)
 	if err != nil {
 		return players, err
 	}
@@ -199,6 +222,7 @@ func getPlayers(tx *sqlx.Tx, gameID int) (map[string]domain.Player, error) {
 			InitialOrder: dbPlayer.InitialOrder,
 			Order:        dbPlayer.COrder,
 			Hand:         hand,
+			Bot:          dbPlayer.Bot,
 		}
 	}
 
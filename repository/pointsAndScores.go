@@ -6,22 +6,6 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-var pointSchema = `
-CREATE TABLE IF NOT EXISTS point (
-	id serial PRIMARY KEY NOT NULL,
-	gameid integer NOT NULL REFERENCES game(id),
-	team  text NOT NULL,
-	value integer NOT NULL
-)`
-
-var scoreSchema = `
-CREATE TABLE IF NOT EXISTS score (
-	id serial PRIMARY KEY NOT NULL,
-	gameid integer NOT NULL REFERENCES game(id),
-	team  text NOT NULL,
-	value integer NOT NULL
-)`
-
 func createPointsOrScore(tx *sqlx.Tx, gameID int, collection string, team string, value int) error {
 	query := fmt.Sprintf(`
 			INSERT INTO %s (gameid, team, value)
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TournamentRepository stores the pairs and the tables of a tournament as json, as they are always read together.
+type TournamentRepository struct {
+	usecases.TournamentRepositoryInterface
+	db *sqlx.DB
+}
+
+func NewTournamentRepository(dsn string) (*TournamentRepository, error) {
+	db := sqlx.MustOpen("pgx", dsn)
+
+	return NewTournamentRepositoryFromDb(db)
+}
+
+func NewTournamentRepositoryFromDb(db *sqlx.DB) (*TournamentRepository, error) {
+	_, err := Migrate(db)
+
+	return &TournamentRepository{db: db}, err
+}
+
+// getPairsAndTables never gives nil, which would be stored as a json null.
+func getPairsAndTables(tournament domain.Tournament) ([]byte, []byte, error) {
+	pairs := tournament.Pairs
+	if pairs == nil {
+		pairs = []domain.Pair{}
+	}
+
+	tables := tournament.Tables
+	if tables == nil {
+		tables = []domain.Table{}
+	}
+
+	pairsJSON, err := json.Marshal(pairs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tablesJSON, err := json.Marshal(tables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pairsJSON, tablesJSON, nil
+}
+
+func (s *TournamentRepository) CreateTournament(tournament domain.Tournament) (int, error) {
+	var tournamentID int
+
+	pairs, tables, err := getPairsAndTables(tournament)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.db.QueryRow(
+		`
+		INSERT INTO tournament (name, format, seed, rules, target, pairs, tables)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+		`,
+		tournament.Name,
+		tournament.Format,
+		tournament.Seed,
+		tournament.Rules,
+		tournament.Target,
+		pairs,
+		tables,
+	).Scan(&tournamentID)
+
+	return tournamentID, err
+}
+
+const selectTournaments = `SELECT id, name, createdAt, format, seed, rules, target, pairs, tables FROM tournament`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTournament(row rowScanner) (domain.Tournament, error) {
+	var tournament domain.Tournament
+	var pairs []byte
+	var tables []byte
+
+	err := row.Scan(
+		&tournament.ID,
+		&tournament.Name,
+		&tournament.CreatedAt,
+		&tournament.Format,
+		&tournament.Seed,
+		&tournament.Rules,
+		&tournament.Target,
+		&pairs,
+		&tables,
+	)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	err = json.Unmarshal(pairs, &tournament.Pairs)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	err = json.Unmarshal(tables, &tournament.Tables)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	return tournament, nil
+}
+
+func (s *TournamentRepository) ListTournaments() ([]domain.Tournament, error) {
+	rows, err := s.db.Query(selectTournaments + ` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournaments := []domain.Tournament{}
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+
+	return tournaments, rows.Err()
+}
+
+func (s *TournamentRepository) GetTournament(tournamentID int) (domain.Tournament, error) {
+	tournament, err := scanTournament(s.db.QueryRow(selectTournaments+` WHERE id=$1`, tournamentID))
+	if err == sql.ErrNoRows {
+		return domain.Tournament{}, errors.New(usecases.ErrTournamentNotFound)
+	}
+
+	return tournament, err
+}
+
+func (s *TournamentRepository) UpdateTournament(tournament domain.Tournament) error {
+	pairs, tables, err := getPairsAndTables(tournament)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(
+		`UPDATE tournament SET pairs = $2, tables = $3 WHERE id = $1`,
+		tournament.ID,
+		pairs,
+		tables,
+	)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.New(usecases.ErrTournamentNotFound)
+	}
+
+	return nil
+}
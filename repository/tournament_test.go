@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"coinche/utilities"
+	testUtilities "coinche/utilities/test"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournamentRepo(test *testing.T) {
+	assert := assert.New(test)
+	dbName := "testtournamentrepodb"
+	utilities.LoadEnv("../.env")
+
+	db, postgres := testUtilities.CreateDb(dbName)
+
+	repository, err := NewTournamentRepositoryFromDb(db)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	tournamentID := 0
+
+	test.Run("create and get a tournament", func(test *testing.T) {
+		tournament, _ := domain.NewTournament("CUP", domain.DuplicateFormat, domain.ContreeFFBRulesName, 0, 42)
+
+		tournamentID, err = repository.CreateTournament(tournament)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := repository.GetTournament(tournamentID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(tournamentID, got.ID)
+		assert.Equal("CUP", got.Name)
+		assert.Equal(int64(42), got.Seed)
+		assert.Equal(domain.ContreeFFBRulesName, got.Rules)
+		assert.Equal(domain.DuplicateFormat, got.Format)
+		assert.Equal(domain.DEFAULT_TARGET, got.Target)
+		assert.Equal([]domain.Pair{}, got.Pairs)
+		assert.IsType(time.Time{}, got.CreatedAt)
+	})
+
+	test.Run("update the pairs and the tables", func(test *testing.T) {
+		tournament, _ := repository.GetTournament(tournamentID)
+		tournament.Pairs = []domain.Pair{{Name: "A", Players: []string{"A1", "A2"}}}
+		tournament.Tables = []domain.Table{{Round: 1, Number: 1, NorthSouth: "A", EastWest: "B", GameID: 3}}
+
+		err := repository.UpdateTournament(tournament)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := repository.GetTournament(tournamentID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(tournament.Pairs, got.Pairs)
+		assert.Equal(tournament.Tables, got.Tables)
+	})
+
+	test.Run("list the tournaments", func(test *testing.T) {
+		tournament, _ := domain.NewTournament("OTHER CUP", domain.KnockoutFormat, "", 1500, 42)
+		_, err := repository.CreateTournament(tournament)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := repository.ListTournaments()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(2, len(got))
+		assert.Equal("CUP", got[0].Name)
+		assert.Equal(domain.KnockoutFormat, got[1].Format)
+		assert.Equal(1500, got[1].Target)
+	})
+
+	test.Run("not get an unknown tournament", func(test *testing.T) {
+		_, err := repository.GetTournament(1000)
+
+		assert.Error(err)
+		assert.Equal(usecases.ErrTournamentNotFound, err.Error())
+	})
+
+	test.Cleanup(func() {
+		testUtilities.DropDb(postgres, dbName, db)
+	})
+}
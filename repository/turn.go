@@ -8,15 +8,6 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-var turnSchema = `
-CREATE TABLE IF NOT EXISTS turn (
-	id serial PRIMARY KEY NOT NULL,
-  position integer NOT NULL,
-	gameid integer NOT NULL REFERENCES game(id),
-	winner  text NOT NULL,
-	plays json NOT NULL DEFAULT '[]'
-)`
-
 func createTurn(turn domain.Turn, tx *sqlx.Tx, gameID int, position int) error {
 	plays, err := json.Marshal(turn.Plays)
 	if err != nil {
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"coinche/domain"
+	"coinche/usecases"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type UserRepository struct {
+	usecases.UserRepositoryInterface
+	db *sqlx.DB
+}
+
+func NewUserRepository(dsn string) (*UserRepository, error) {
+	db := sqlx.MustOpen("pgx", dsn)
+
+	return NewUserRepositoryFromDb(db)
+}
+
+func NewUserRepositoryFromDb(db *sqlx.DB) (*UserRepository, error) {
+	_, err := Migrate(db)
+
+	return &UserRepository{db: db}, err
+}
+
+func (s *UserRepository) CreateUser(user domain.User) (int, error) {
+	var userID int
+
+	err := s.db.QueryRow(
+		`
+		INSERT INTO account (name, passwordHash)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id
+		`,
+		user.Name,
+		user.PasswordHash,
+	).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, errors.New(domain.ErrUserAlreadyExists)
+	}
+
+	return userID, err
+}
+
+func (s *UserRepository) GetUser(name string) (domain.User, error) {
+	var user domain.User
+
+	err := s.db.QueryRow(
+		`SELECT id, name, passwordHash, createdAt FROM account WHERE name=$1`,
+		name,
+	).Scan(
+		&user.ID,
+		&user.Name,
+		&user.PasswordHash,
+		&user.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return domain.User{}, errors.New(domain.ErrUserNotFound)
+	}
+
+	return user, err
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"coinche/domain"
+	"coinche/utilities"
+	testUtilities "coinche/utilities/test"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepo(test *testing.T) {
+	assert := assert.New(test)
+	dbName := "testuserrepodb"
+	utilities.LoadEnv("../.env")
+
+	db, postgres := testUtilities.CreateDb(dbName)
+
+	repository, err := NewUserRepositoryFromDb(db)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("create and get a user", func(test *testing.T) {
+		newID, err := repository.CreateUser(domain.User{Name: "P1", PasswordHash: "HASH"})
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, err := repository.GetUser("P1")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		assert.Equal(newID, got.ID)
+		assert.Equal("P1", got.Name)
+		assert.Equal("HASH", got.PasswordHash)
+		assert.IsType(time.Time{}, got.CreatedAt)
+	})
+
+	test.Run("not create a user twice", func(test *testing.T) {
+		_, err := repository.CreateUser(domain.User{Name: "P1", PasswordHash: "OTHER HASH"})
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUserAlreadyExists, err.Error())
+	})
+
+	test.Run("not get an unknown user", func(test *testing.T) {
+		_, err := repository.GetUser("P2")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUserNotFound, err.Error())
+	})
+
+	test.Cleanup(func() {
+		testUtilities.DropDb(postgres, dbName, db)
+	})
+}
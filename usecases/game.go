@@ -1,31 +1,59 @@
 package usecases
 
 import (
+	"coinche/bot"
 	"coinche/domain"
+	"errors"
 	"time"
 )
 
 type GameUsecasesInterface interface {
 	ListGames() ([]GamePreview, error)
 	GetGame(gameID int) (domain.Game, error)
-	CreateGame(name string) int
+	CreateGame(name string, target int, rules string, dealing domain.DealingPattern, seed int64) (int, error)
 	JoinGame(gameID int, playerName string) (domain.Game, error)
 	LeaveGame(gameID int, playerName string) error
 	DeleteGame(gameID int) error
 }
 
+const (
+	ErrVersionConflict         = "GAME HAS BEEN UPDATED CONCURRENTLY"
+	ErrGameNotFound            = "GAME NOT FOUND"
+	ErrCannotDeleteWithPlayers = "CANNOT DELETE GAME WITH PLAYERS"
+)
+
+// MAX_UPDATE_ATTEMPTS bounds the retries of a command which keeps conflicting with concurrent updates.
+const MAX_UPDATE_ATTEMPTS = 5
+
+// GameRepositoryInterface updates a game only if its version is still the one which has been read, and fails with
+// ErrVersionConflict otherwise.
 type GameRepositoryInterface interface {
 	ListGames() ([]domain.Game, error)
 	GetGame(gameID int) (domain.Game, error)
-	CreateGame(game domain.Game) (int, error)
-	UpdatePlayer(gameID int, playerName string, players domain.Player) error
-	UpdateGame(game domain.Game) error
+	CreateGame(game domain.Game, events ...domain.Event) (int, error)
+	UpdatePlayer(gameID int, version int, playerName string, players domain.Player, events ...domain.Event) error
+	UpdateGame(game domain.Game, events ...domain.Event) error
+	// ArchiveAndUpdateGame keeps a copy of the deal as stored at the version of the game, and updates the game in
+	// the same write, so a conflicting update archives nothing.
+	ArchiveAndUpdateGame(game domain.Game, events ...domain.Event) error
 	DeleteGame(gameID int) error
+	GetEvents(gameID int) ([]domain.Event, error)
+	AddChatMessage(gameID int, message domain.ChatMessage) error
+	GetChatMessages(gameID int) ([]domain.ChatMessage, error)
+}
+
+// EntrantsInterface tells whether a player is one of the entrants a tournament has seated in the game, and whether the
+// game can be seen by the others before the end of its round.
+type EntrantsInterface interface {
+	IsEntrant(gameID int, playerName string) (bool, error)
+	CheckGameVisible(gameID int) error
 }
 
 type GameUsecases struct {
 	GameUsecasesInterface
 	Repo GameRepositoryInterface
+	// Entrants is nil when the games are not run in tournaments.
+	Entrants EntrantsInterface
 }
 
 type GamePreview struct {
@@ -35,6 +63,24 @@ type GamePreview struct {
 	Players    []string
 	TurnsCount int
 	CreatedAt  time.Time
+	Target     int
+	Scores     map[string]int
+	Winner     string
+	Rules      string
+	Spectators []string
+}
+
+// retryOnConflict runs the command again against the fresh state of the game when another command has updated it
+// in the meantime, so concurrent commands are applied one after the other.
+func retryOnConflict(command func() error) error {
+	var err error
+	for attempt := 0; attempt < MAX_UPDATE_ATTEMPTS; attempt++ {
+		err = command()
+		if err == nil || err.Error() != ErrVersionConflict {
+			return err
+		}
+	}
+	return err
 }
 
 func (s *GameUsecases) ListGames() ([]GamePreview, error) {
@@ -57,6 +103,11 @@ func (s *GameUsecases) ListGames() ([]GamePreview, error) {
 			Players:    playersNames,
 			TurnsCount: len(game.Turns),
 			CreatedAt:  game.CreatedAt,
+			Target:     game.Target,
+			Scores:     game.Scores,
+			Winner:     game.Winner,
+			Rules:      game.Rules.Name,
+			Spectators: game.Spectators,
 		}
 	}
 
@@ -67,159 +118,482 @@ func (s *GameUsecases) GetGame(gameID int) (domain.Game, error) {
 	return s.Repo.GetGame(gameID)
 }
 
-func (s *GameUsecases) CreateGame(name string) (int, error) {
-	game := domain.NewGame(name)
-	return s.Repo.CreateGame(game)
+// CreateGame draws a new seed when none is given.
+func (s *GameUsecases) CreateGame(name string, target int, rules string, dealing domain.DealingPattern, seed int64) (int, error) {
+	if seed == 0 {
+		seed = domain.NewSeed()
+	}
+	game := domain.NewGameWithSeed(name, seed)
+
+	if target != 0 {
+		err := game.SetTarget(target)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err := game.SetRules(rules)
+	if err != nil {
+		return 0, err
+	}
+
+	err = game.SetDealingPattern(dealing)
+	if err != nil {
+		return 0, err
+	}
+
+	payload := domain.EventPayload{Name: name, Target: game.Target, Rules: game.Rules.Name, Dealing: dealing, Seed: seed}
+	event := domain.NewEvent(domain.CreateEvent, "", payload)
+	return s.Repo.CreateGame(game, event)
 }
 
 func (s *GameUsecases) DeleteGame(gameID int) error {
 	return s.Repo.DeleteGame(gameID)
 }
 
-func (s *GameUsecases) JoinGame(gameID int, playerName string) (domain.Game, error) {
-	game, err := s.Repo.GetGame(gameID)
-	if err != nil {
-		return domain.Game{}, err
+func (s *GameUsecases) isEntrant(gameID int, playerName string) (bool, error) {
+	if s.Entrants == nil {
+		return false, nil
 	}
+	return s.Entrants.IsEntrant(gameID, playerName)
+}
 
-	if game.Phase == domain.Teaming {
-		err = game.AddPlayer(playerName)
+func (s *GameUsecases) checkGameVisible(gameID int) error {
+	if s.Entrants == nil {
+		return nil
+	}
+	return s.Entrants.CheckGameVisible(gameID)
+}
+
+// JoinGame lets a player take its seat back in a started game, or in a game whose team has been chosen for it by a
+// tournament. A player without a seat only observes a started game, once its round is over in a tournament. The seat
+// of a bot is never given to a player.
+func (s *GameUsecases) JoinGame(gameID int, playerName string) (domain.Game, error) {
+	err := retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
 		if err != nil {
-			return domain.Game{}, err
+			return err
+		}
+
+		player, ok := game.Players[playerName]
+		if ok && player.IsBot() {
+			return errors.New(domain.ErrBotSeat)
+		}
+
+		if game.Phase != domain.Teaming {
+			if ok {
+				return nil
+			}
+			return s.checkGameVisible(gameID)
+		}
+
+		if ok && player.Team != "" {
+			isEntrant, err := s.isEntrant(gameID, playerName)
+			if err != nil {
+				return err
+			}
+			if isEntrant {
+				return nil
+			}
 		}
-		err = s.Repo.UpdateGame(game)
+
+		err = game.AddPlayer(playerName)
 		if err != nil {
-			return domain.Game{}, err
+			return err
 		}
-		game, err = s.Repo.GetGame(gameID)
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.JoinEvent, playerName, domain.EventPayload{}))
+	})
+	if err != nil {
+		return domain.Game{}, err
 	}
 
-	return game, err
+	return s.Repo.GetGame(gameID)
 }
 
-func (s *GameUsecases) LeaveGame(gameID int, playerName string) error {
-	game, err := s.Repo.GetGame(gameID)
+func (s *GameUsecases) WatchGame(gameID int, spectatorName string) (domain.Game, error) {
+	err := s.checkGameVisible(gameID)
 	if err != nil {
-		return err
+		return domain.Game{}, err
 	}
 
-	if game.Phase == domain.Teaming {
-		err = game.RemovePlayer(playerName)
+	err = retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
 		if err != nil {
 			return err
 		}
-		err = s.Repo.UpdateGame(game)
+
+		err = game.AddSpectator(spectatorName)
 		if err != nil {
 			return err
 		}
-		game, err = s.Repo.GetGame(gameID)
+
+		return s.Repo.UpdateGame(game)
+	})
+	if err != nil {
+		return domain.Game{}, err
 	}
 
-	return err
+	return s.Repo.GetGame(gameID)
 }
 
-func (s *GameUsecases) JoinTeam(gameID int, playerName string, teamName string) error {
-	game, err := s.Repo.GetGame(gameID)
-	if err != nil {
-		return err
-	}
-	err = game.AssignTeam(playerName, teamName)
+func (s *GameUsecases) StopWatching(gameID int, spectatorName string) (domain.Game, error) {
+	err := retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.RemoveSpectator(spectatorName)
+		if err != nil {
+			return err
+		}
+
+		return s.Repo.UpdateGame(game)
+	})
 	if err != nil {
-		return err
+		return domain.Game{}, err
 	}
-	err = s.Repo.UpdatePlayer(game.ID, playerName, game.Players[playerName])
-	return err
+
+	return s.Repo.GetGame(gameID)
+}
+
+func (s *GameUsecases) LeaveGame(gameID int, playerName string) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		if game.Phase != domain.Teaming {
+			return nil
+		}
+
+		err = game.RemovePlayer(playerName)
+		if err != nil {
+			return err
+		}
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.LeaveEvent, playerName, domain.EventPayload{}))
+	})
+}
+
+func (s *GameUsecases) JoinTeam(gameID int, playerName string, teamName string) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		err = game.AssignTeam(playerName, teamName)
+		if err != nil {
+			return err
+		}
+		event := domain.NewEvent(domain.JoinTeamEvent, playerName, domain.EventPayload{Team: teamName})
+		return s.Repo.UpdatePlayer(game.ID, game.Version, playerName, game.Players[playerName], event)
+	})
 }
 
 func (s *GameUsecases) LeaveTeam(gameID int, playerName string) error {
-	game, err := s.Repo.GetGame(gameID)
-	if err != nil {
-		return err
-	}
-	err = game.ClearTeam(playerName)
-	if err != nil {
-		return err
-	}
-	err = s.Repo.UpdatePlayer(game.ID, playerName, game.Players[playerName])
-	return err
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		err = game.ClearTeam(playerName)
+		if err != nil {
+			return err
+		}
+		event := domain.NewEvent(domain.LeaveTeamEvent, playerName, domain.EventPayload{})
+		return s.Repo.UpdatePlayer(game.ID, game.Version, playerName, game.Players[playerName], event)
+	})
 }
 
 func (s *GameUsecases) StartGame(gameID int) error {
-	game, err := s.Repo.GetGame(gameID)
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		isDealOver := game.Phase == domain.Counting
+		err = game.Start()
+		if err != nil {
+			return err
+		}
+
+		event := domain.NewEvent(domain.StartEvent, "", domain.EventPayload{})
+		if isDealOver {
+			return s.Repo.ArchiveAndUpdateGame(game, event)
+		}
+		return s.Repo.UpdateGame(game, event)
+	})
+}
+
+func (s *GameUsecases) Cut(gameID int, playerName string, position int) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.Cut(playerName, position)
+		if err != nil {
+			return err
+		}
+		payload := domain.EventPayload{Position: position, Hands: game.Hands()}
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.CutEvent, playerName, payload))
+	})
+}
+
+func (s *GameUsecases) Bid(gameID int, playerName string, value domain.BidValue, color domain.Color) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.PlaceBid(playerName, value, color)
+		if err != nil {
+			return err
+		}
+		event := domain.NewEvent(domain.BidEvent, playerName, domain.EventPayload{Value: value, Color: color})
+		return s.Repo.UpdateGame(game, event)
+	})
+}
+
+func (s *GameUsecases) Pass(gameID int, playerName string) (bool, error) {
+	hasRedealt := false
+	err := retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		redeals := game.Redeals
+
+		err = game.Pass(playerName)
+		if err != nil {
+			return err
+		}
+
+		hasRedealt = game.Redeals > redeals
+
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.PassEvent, playerName, domain.EventPayload{}))
+	})
+	return hasRedealt, err
+}
+
+func (s *GameUsecases) Coinche(gameID int, playerName string) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.Coinche(playerName)
+		if err != nil {
+			return err
+		}
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.CoincheEvent, playerName, domain.EventPayload{}))
+	})
+}
+
+func (s *GameUsecases) Surcoinche(gameID int, playerName string) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.Surcoinche(playerName)
+		if err != nil {
+			return err
+		}
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.SurcoincheEvent, playerName, domain.EventPayload{}))
+	})
+}
+
+func (s *GameUsecases) PlayCard(gameID int, playerName string, card domain.CardID) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.Play(playerName, card)
+		if err != nil {
+			return err
+		}
+
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.PlayEvent, playerName, domain.EventPayload{Card: card}))
+	})
+}
+
+func (s *GameUsecases) Declare(gameID int, playerName string, cards []domain.CardID) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		err = game.Declare(playerName, cards)
+		if err != nil {
+			return err
+		}
+
+		return s.Repo.UpdateGame(game, domain.NewEvent(domain.DeclareEvent, playerName, domain.EventPayload{Cards: cards}))
+	})
+}
+
+func (s *GameUsecases) AddBot(gameID int, strategy string, teamName string) (string, error) {
+	_, err := bot.NewStrategy(strategy)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if game.Phase == domain.Counting {
-		// archive current game
-		_, err = s.Repo.CreateGame(game)
+
+	botName := ""
+	err = retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
 		if err != nil {
 			return err
 		}
-	}
-	err = game.Start()
+
+		botName, err = game.AddBot(strategy, teamName)
+		if err != nil {
+			return err
+		}
+
+		event := domain.NewEvent(domain.AddBotEvent, botName, domain.EventPayload{Bot: strategy, Team: game.Players[botName].Team})
+		return s.Repo.UpdateGame(game, event)
+	})
 	if err != nil {
-		return err
+		return "", err
 	}
-	err = s.Repo.UpdateGame(game)
-	return err
+	return botName, nil
 }
 
-func (s *GameUsecases) Bid(gameID int, playerName string, value domain.BidValue, color domain.Color) error {
+// PlayBot makes the bot act once with its strategy. If its bid is refused, the bot passes instead.
+// It returns whether the cards have been redealt.
+func (s *GameUsecases) PlayBot(gameID int, botName string) (bool, error) {
 	game, err := s.Repo.GetGame(gameID)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	player, ok := game.Players[botName]
+	if !ok {
+		return false, errors.New(domain.ErrPlayerNotFound)
+	}
+	if !player.IsBot() {
+		return false, errors.New(bot.ErrNotABot)
 	}
 
-	err = game.PlaceBid(playerName, value, color)
+	strategy, err := bot.NewStrategy(player.Bot)
 	if err != nil {
-		return err
+		return false, err
 	}
-	err = s.Repo.UpdateGame(game)
-	return err
+
+	if game.Phase == domain.Cutting {
+		return false, s.Cut(gameID, botName, domain.RANDOM_CUT)
+	}
+
+	view := game.ViewFor(botName)
+
+	if game.Phase == domain.Bidding {
+		choice := strategy.ChooseBid(view, botName)
+
+		if choice.Action == bot.Coinche && s.Coinche(gameID, botName) == nil {
+			return false, nil
+		}
+
+		if choice.Action == bot.Bid && s.Bid(gameID, botName, choice.Value, choice.Color) == nil {
+			return false, nil
+		}
+
+		return s.Pass(gameID, botName)
+	}
+
+	legalCards := game.LegalCards(botName)
+	if len(legalCards) == 0 {
+		return false, errors.New(bot.ErrNothingToPlay)
+	}
+
+	card := strategy.ChooseCard(view, botName, legalCards)
+	return false, s.PlayCard(gameID, botName, card)
 }
 
-func (s *GameUsecases) Pass(gameID int, playerName string) error {
+// PlayOnTimeout cuts randomly, passes or plays the lowest legal card for a player who has not acted in time. It returns
+// true when passing has redealt the cards.
+func (s *GameUsecases) PlayOnTimeout(gameID int, playerName string) (bool, error) {
 	game, err := s.Repo.GetGame(gameID)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	if game.PlayerToAct() != playerName {
+		return false, errors.New(domain.ErrNotYourTurn)
+	}
+
+	if game.Phase == domain.Cutting {
+		return false, s.Cut(gameID, playerName, domain.RANDOM_CUT)
 	}
 
-	err = game.Pass(playerName)
+	if game.Phase == domain.Bidding {
+		return s.Pass(gameID, playerName)
+	}
+
+	card, err := game.LowestLegalCard(playerName)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	err = s.Repo.UpdateGame(game)
-	return err
+	return false, s.PlayCard(gameID, playerName, card)
 }
 
-func (s *GameUsecases) Coinche(gameID int, playerName string) error {
+func (s *GameUsecases) GetTimeline(gameID int) (domain.Timeline, error) {
 	game, err := s.Repo.GetGame(gameID)
 	if err != nil {
-		return err
+		return domain.Timeline{}, err
 	}
 
-	err = game.Coinche(playerName)
+	return game.GetTimeline()
+}
+
+func (s *GameUsecases) GetEvents(gameID int) ([]domain.Event, error) {
+	return s.Repo.GetEvents(gameID)
+}
+
+// GetEventsSince gives the events missed by a player, as this player is allowed to see them.
+func (s *GameUsecases) GetEventsSince(gameID int, sequence int, playerName string) ([]domain.Event, error) {
+	events, err := s.Repo.GetEvents(gameID)
 	if err != nil {
-		return err
+		return []domain.Event{}, err
 	}
-	err = s.Repo.UpdateGame(game)
-	return err
+
+	missedEvents := []domain.Event{}
+	for _, event := range events {
+		if event.Sequence > sequence {
+			missedEvents = append(missedEvents, event.ViewFor(playerName))
+		}
+	}
+
+	return missedEvents, nil
 }
 
-func (s *GameUsecases) PlayCard(gameID int, playerName string, card domain.CardID) error {
+// Reconnect gives the game back to a player already seated in it, whatever the phase.
+func (s *GameUsecases) Reconnect(gameID int, playerName string) (domain.Game, error) {
 	game, err := s.Repo.GetGame(gameID)
 	if err != nil {
-		return err
+		return domain.Game{}, err
 	}
 
-	err = game.Play(playerName, card)
-	if err != nil {
-		return err
+	player, ok := game.Players[playerName]
+	if !ok {
+		return domain.Game{}, errors.New(domain.ErrPlayerNotFound)
 	}
 
-	err = s.Repo.UpdateGame(game)
-	return err
+	if player.IsBot() {
+		return domain.Game{}, errors.New(domain.ErrBotSeat)
+	}
+
+	return game, nil
 }
 
 func NewGameUsecases(repository GameRepositoryInterface) *GameUsecases {
@@ -227,13 +601,50 @@ func NewGameUsecases(repository GameRepositoryInterface) *GameUsecases {
 }
 
 func (s *GameUsecases) ArchiveGame(gameID int) error {
+	return retryOnConflict(func() error {
+		game, err := s.Repo.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+
+		game.Root = 0
+
+		return s.Repo.UpdateGame(game)
+	})
+}
+
+func (s *GameUsecases) addChatMessage(gameID int, message domain.ChatMessage) (domain.ChatMessage, error) {
 	game, err := s.Repo.GetGame(gameID)
 	if err != nil {
-		return err
+		return domain.ChatMessage{}, err
 	}
 
-	game.Root = 0
+	if !game.CanChat(message.Sender) {
+		return domain.ChatMessage{}, errors.New(domain.ErrNotInGame)
+	}
 
-	err = s.Repo.UpdateGame(game)
-	return err
+	return message, s.Repo.AddChatMessage(gameID, message)
+}
+
+func (s *GameUsecases) Chat(gameID int, sender string, text string) (domain.ChatMessage, error) {
+	message, err := domain.NewChatMessage(sender, text)
+	if err != nil {
+		return domain.ChatMessage{}, err
+	}
+
+	return s.addChatMessage(gameID, message)
+}
+
+func (s *GameUsecases) React(gameID int, sender string, reaction string) (domain.ChatMessage, error) {
+	message, err := domain.NewReaction(sender, reaction)
+	if err != nil {
+		return domain.ChatMessage{}, err
+	}
+
+	return s.addChatMessage(gameID, message)
+}
+
+// GetChatHistory returns the last messages of the game, the oldest first.
+func (s *GameUsecases) GetChatHistory(gameID int) ([]domain.ChatMessage, error) {
+	return s.Repo.GetChatMessages(gameID)
 }
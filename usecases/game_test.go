@@ -1,7 +1,9 @@
 package usecases
 
 import (
+	"coinche/bot"
 	"coinche/domain"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,7 +54,7 @@ func TestGameService(test *testing.T) {
 	})
 
 	test.Run("can create game", func(test *testing.T) {
-		gameID, err := gameUsecases.CreateGame("GAME TWO")
+		gameID, err := gameUsecases.CreateGame("GAME TWO", 0, "", "", 0)
 		if err != nil {
 			test.Fatal(err)
 		}
@@ -95,8 +97,30 @@ func TestGameService(test *testing.T) {
 
 		game, err := gameUsecases.GetGame(1)
 
+		assert.NoError(err)
+		assert.Equal(domain.Cutting, game.Phase)
+		assert.Equal("P4", game.Dealer)
+	})
+
+	test.Run("can cut", func(test *testing.T) {
+		err := gameUsecases.Cut(1, "P1", 16)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(1)
+
 		assert.NoError(err)
 		assert.Equal(domain.Bidding, game.Phase)
+		assert.Equal(8, len(game.Players["P1"].Hand))
+
+		events, err := gameUsecases.GetEvents(1)
+
+		assert.NoError(err)
+		cut := events[len(events)-1]
+		assert.Equal(domain.CutEvent, cut.Kind)
+		assert.Equal(16, cut.Payload.Position)
+		assert.Equal(game.Hands(), cut.Payload.Hands)
 	})
 
 	test.Run("can bid", func(test *testing.T) {
@@ -108,11 +132,7 @@ func TestGameService(test *testing.T) {
 		game, err := gameUsecases.GetGame(1)
 
 		assert.NoError(err)
-		assert.Equal(domain.Spade, game.Bids[80].Color)
-		assert.Equal("P1", game.Bids[80].Player)
-
-		assert.Equal(0, game.Bids[80].Pass)
-		assert.Equal(0, game.Bids[80].Coinche)
+		assert.Equal([]domain.BidAction{{Sequence: 1, Kind: domain.PlaceBidAction, Player: "P1", Value: 80, Color: domain.Spade}}, game.Bids)
 
 		assert.Equal(1, game.Players["P3"].Order)
 		assert.Equal(2, game.Players["P2"].Order)
@@ -121,37 +141,42 @@ func TestGameService(test *testing.T) {
 	})
 
 	test.Run("can pass", func(test *testing.T) {
-		err := gameUsecases.Pass(1, "P3")
+		_, err := gameUsecases.Pass(1, "P3")
 		if err != nil {
 			test.Fatal(err)
 		}
 
 		game, err := gameUsecases.GetGame(1)
 
+		lastBid, _ := domain.GetLastBid(game.Bids)
 		assert.NoError(err)
-		assert.Equal(1, game.Bids[80].Pass)
-		assert.Equal(0, game.Bids[80].Coinche)
+		assert.Equal(domain.BidAction{Sequence: 2, Kind: domain.PassAction, Player: "P3"}, game.Bids[1])
+		assert.Equal(1, lastBid.Pass)
+		assert.Equal(0, lastBid.Coinche)
+
+		_, err = gameUsecases.Pass(1, "P2")
+		if err != nil {
+			test.Fatal(err)
+		}
 	})
 
 	test.Run("can coinche", func(test *testing.T) {
-		err := gameUsecases.Coinche(1, "P1")
+		err := gameUsecases.Coinche(1, "P4")
 		if err != nil {
 			test.Fatal(err)
 		}
 
 		game, err := gameUsecases.GetGame(1)
 
+		lastBid, _ := domain.GetLastBid(game.Bids)
 		assert.NoError(err)
-		assert.Equal(0, game.Bids[80].Pass)
-		assert.Equal(1, game.Bids[80].Coinche)
+		assert.Equal(domain.BidAction{Sequence: 4, Kind: domain.CoincheAction, Player: "P4"}, game.Bids[3])
+		assert.Equal(0, lastBid.Pass)
+		assert.Equal(1, lastBid.Coinche)
 	})
 
-	test.Run("can go to playing phase", func(test *testing.T) {
-		err := gameUsecases.Pass(1, "P3")
-		if err != nil {
-			test.Fatal(err)
-		}
-		err = gameUsecases.Pass(1, "P4")
+	test.Run("can surcoinche and go to playing phase", func(test *testing.T) {
+		err := gameUsecases.Surcoinche(1, "P2")
 		if err != nil {
 			test.Fatal(err)
 		}
@@ -200,3 +225,799 @@ func TestGameService(test *testing.T) {
 		assert.Equal(0, game.Root)
 	})
 }
+
+func TestRedeal(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team"},
+		"P2": {Team: "B Team"},
+		"P3": {Team: "A Team"},
+		"P4": {Team: "B Team"},
+	}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	startAndCut(test, gameUsecases, 1)
+
+	test.Run("should not redeal before the fourth pass", func(test *testing.T) {
+		for _, name := range []string{"P1", "P2", "P3"} {
+			hasRedealt, err := gameUsecases.Pass(1, name)
+
+			assert.NoError(err)
+			assert.False(hasRedealt)
+		}
+	})
+
+	test.Run("should redeal when everyone passes", func(test *testing.T) {
+		hasRedealt, err := gameUsecases.Pass(1, "P4")
+
+		assert.NoError(err)
+		assert.True(hasRedealt)
+
+		game, err := gameUsecases.GetGame(1)
+
+		assert.NoError(err)
+		assert.Equal(domain.Cutting, game.Phase)
+		assert.Equal(1, game.Redeals)
+		assert.Equal(0, len(game.Bids))
+		assert.Equal(1, game.Players["P2"].Order)
+		assert.Equal(32, len(game.Deck))
+	})
+
+	test.Run("should deal again once cut", func(test *testing.T) {
+		err := gameUsecases.Cut(1, "P2", domain.RANDOM_CUT)
+		assert.NoError(err)
+
+		game, err := gameUsecases.GetGame(1)
+
+		assert.NoError(err)
+		assert.Equal(domain.Bidding, game.Phase)
+		assert.Equal(8, len(game.Players["P1"].Hand))
+	})
+}
+
+// startAndCut starts the game and lets the player on the left of the dealer cut the deck.
+func startAndCut(test *testing.T, gameUsecases *GameUsecases, gameID int) {
+	err := gameUsecases.StartGame(gameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	game, err := gameUsecases.GetGame(gameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = gameUsecases.Cut(gameID, game.PlayerToAct(), domain.RANDOM_CUT)
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+func TestMatchEnd(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Phase = domain.Finished
+	game.Winner = "A Team"
+	game.Scores = map[string]int{"A Team": 1020, "B Team": 640}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	test.Run("can create a game with a target", func(test *testing.T) {
+		gameID, err := gameUsecases.CreateGame("GAME TWO", 1500, "", "", 0)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(gameID)
+
+		assert.NoError(err)
+		assert.Equal(1500, game.Target)
+	})
+
+	test.Run("can create a game with rules", func(test *testing.T) {
+		gameID, err := gameUsecases.CreateGame("GAME FFB", 0, domain.ContreeFFBRulesName, "", 0)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(gameID)
+
+		assert.NoError(err)
+		assert.Equal(domain.ContreeFFBRules, game.Rules)
+		assert.Equal(1000, game.Target)
+	})
+
+	test.Run("can create a game from a known seed", func(test *testing.T) {
+		gameID, err := gameUsecases.CreateGame("GAME SEED", 0, "", "", 42)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(gameID)
+
+		assert.NoError(err)
+		assert.Equal(int64(42), game.Seed)
+		assert.Equal(domain.NewGameWithSeed("GAME SEED", 42).Deck, game.Deck)
+
+		events, err := gameUsecases.GetEvents(gameID)
+
+		assert.NoError(err)
+		assert.Equal(int64(42), events[0].Payload.Seed)
+	})
+
+	test.Run("draws a seed when none is given", func(test *testing.T) {
+		gameID, err := gameUsecases.CreateGame("GAME RANDOM", 0, "", "", 0)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(gameID)
+
+		assert.NoError(err)
+		assert.NotEqual(int64(0), game.Seed)
+	})
+
+	test.Run("cannot create a game with unknown rules", func(test *testing.T) {
+		_, err := gameUsecases.CreateGame("GAME FOUR", 0, "unknown", "", 0)
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUnknownRules, err.Error())
+	})
+
+	test.Run("cannot create a game with an invalid target", func(test *testing.T) {
+		_, err := gameUsecases.CreateGame("GAME THREE", 42, "", "", 0)
+
+		assert.Error(err)
+		assert.Equal(domain.ErrInvalidTarget, err.Error())
+	})
+
+	test.Run("cannot start a new deal once the match is over", func(test *testing.T) {
+		creationCalls := mockRepository.creationCalls
+
+		err := gameUsecases.StartGame(1)
+
+		assert.Error(err)
+		assert.Equal(domain.ErrMatchFinished, err.Error())
+		assert.Equal(creationCalls, mockRepository.creationCalls)
+	})
+
+	test.Run("preview exposes the final result", func(test *testing.T) {
+		previews, err := gameUsecases.ListGames()
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		for _, preview := range previews {
+			if preview.ID != 1 {
+				continue
+			}
+			assert.Equal(domain.Finished, preview.Phase)
+			assert.Equal("A Team", preview.Winner)
+			assert.Equal(1020, preview.Scores["A Team"])
+			assert.Equal(1000, preview.Target)
+			assert.Equal(domain.ClassicRulesName, preview.Rules)
+		}
+	})
+}
+
+func TestDeclare(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Phase = domain.Playing
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team", Order: 1, InitialOrder: 1, Hand: []domain.CardID{domain.C7, domain.C8, domain.C9, domain.DJ, domain.DQ, domain.HJ, domain.HQ, domain.HK}},
+		"P2": {Team: "B Team", Order: 2, InitialOrder: 2, Hand: []domain.CardID{domain.C10, domain.CJ, domain.CQ, domain.DK, domain.DA, domain.HA, domain.S7, domain.S8}},
+		"P3": {Team: "A Team", Order: 3, InitialOrder: 3, Hand: []domain.CardID{domain.CK, domain.CA, domain.D7, domain.H7, domain.H8, domain.S9, domain.S10, domain.SJ}},
+		"P4": {Team: "B Team", Order: 4, InitialOrder: 4, Hand: []domain.CardID{domain.D8, domain.D9, domain.D10, domain.H9, domain.H10, domain.SQ, domain.SK, domain.SA}},
+	}
+	game.Bids = []domain.BidAction{
+		{Kind: domain.PlaceBidAction, Player: "P1", Value: domain.Eighty, Color: domain.Heart},
+	}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	test.Run("can declare", func(test *testing.T) {
+		err := gameUsecases.Declare(1, "P1", []domain.CardID{domain.HJ, domain.HQ, domain.HK})
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(1)
+
+		assert.NoError(err)
+		assert.Equal([]domain.Declaration{{Player: "P1", Cards: []domain.CardID{domain.HJ, domain.HQ, domain.HK}}}, game.Declarations)
+	})
+
+	test.Run("cannot declare an invalid declaration", func(test *testing.T) {
+		err := gameUsecases.Declare(1, "P2", []domain.CardID{domain.C10, domain.DK})
+
+		assert.Error(err)
+		assert.Equal(domain.ErrInvalidDeclaration, err.Error())
+	})
+}
+
+func TestBots(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team"},
+		"P2": {Team: "B Team"},
+	}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	test.Run("can add a bot", func(test *testing.T) {
+		botName, err := gameUsecases.AddBot(1, bot.HeuristicStrategyName, "")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, err := gameUsecases.GetGame(1)
+
+		assert.NoError(err)
+		assert.Equal("Bot 1", botName)
+		assert.Equal(domain.Player{Team: "A Team", Bot: bot.HeuristicStrategyName}, game.Players[botName])
+	})
+
+	test.Run("cannot add a bot with an unknown strategy", func(test *testing.T) {
+		_, err := gameUsecases.AddBot(1, "genius", "")
+
+		assert.Error(err)
+		assert.Equal(bot.ErrUnknownStrategy, err.Error())
+	})
+
+	test.Run("bots play when it is their turn", func(test *testing.T) {
+		_, err := gameUsecases.AddBot(1, bot.RandomStrategyName, "")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		err = gameUsecases.StartGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		game, _ := gameUsecases.GetGame(1)
+		assert.Equal("Bot 1", game.BotToPlay())
+
+		_, err = gameUsecases.PlayBot(1, "Bot 1")
+		assert.NoError(err)
+
+		game, _ = gameUsecases.GetGame(1)
+		assert.Equal(domain.Bidding, game.Phase)
+		assert.Equal("Bot 1", game.BotToPlay())
+
+		_, err = gameUsecases.PlayBot(1, "Bot 1")
+		assert.NoError(err)
+
+		game, _ = gameUsecases.GetGame(1)
+		assert.Equal("Bot 2", game.BotToPlay())
+	})
+
+	test.Run("a human cannot take the seat of a bot", func(test *testing.T) {
+		_, err := gameUsecases.JoinGame(1, "Bot 1")
+		assert.Error(err)
+		assert.Equal(domain.ErrBotSeat, err.Error())
+
+		_, err = gameUsecases.Reconnect(1, "Bot 1")
+		assert.Error(err)
+		assert.Equal(domain.ErrBotSeat, err.Error())
+	})
+
+	test.Run("a human is not played by a bot", func(test *testing.T) {
+		_, err := gameUsecases.PlayBot(1, "P1")
+
+		assert.Error(err)
+		assert.Equal(bot.ErrNotABot, err.Error())
+	})
+
+	test.Run("bots can play a whole deal", func(test *testing.T) {
+		game := domain.NewGame("GAME TWO")
+		mockRepository := NewMockGameRepo(
+			map[int]domain.Game{1: game},
+		)
+		gameUsecases := NewGameUsecases(&mockRepository)
+
+		for i := 0; i < 4; i++ {
+			_, err := gameUsecases.AddBot(1, bot.HeuristicStrategyName, "")
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		err := gameUsecases.StartGame(1)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		for i := 0; i < 200; i++ {
+			game, _ := gameUsecases.GetGame(1)
+			botName := game.BotToPlay()
+			if botName == "" {
+				break
+			}
+
+			_, err := gameUsecases.PlayBot(1, botName)
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		game, _ = gameUsecases.GetGame(1)
+		assert.Equal(domain.Counting, game.Phase)
+		assert.Equal(8, len(game.Turns))
+	})
+}
+
+func TestEvents(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	gameID, err := gameUsecases.CreateGame("GAME ONE", 1500, domain.ClassicRulesName, "", 0)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("should log the accepted commands only", func(test *testing.T) {
+		_, err := gameUsecases.JoinGame(gameID, "P1")
+		assert.NoError(err)
+		err = gameUsecases.JoinTeam(gameID, "P1", "A Team")
+		assert.NoError(err)
+		err = gameUsecases.StartGame(gameID)
+		assert.Error(err)
+		err = gameUsecases.LeaveTeam(gameID, "P1")
+		assert.NoError(err)
+		err = gameUsecases.LeaveGame(gameID, "P1")
+		assert.NoError(err)
+
+		events, err := gameUsecases.GetEvents(gameID)
+
+		assert.NoError(err)
+		kinds := []domain.EventKind{}
+		for i, event := range events {
+			assert.Equal(i+1, event.Sequence)
+			kinds = append(kinds, event.Kind)
+		}
+		assert.Equal([]domain.EventKind{domain.CreateEvent, domain.JoinEvent, domain.JoinTeamEvent, domain.LeaveTeamEvent, domain.LeaveEvent}, kinds)
+		assert.Equal(domain.EventPayload{Team: "A Team"}, events[2].Payload)
+	})
+
+	test.Run("should rebuild a whole deal from the events", func(test *testing.T) {
+		for i := 0; i < 4; i++ {
+			_, err := gameUsecases.AddBot(gameID, bot.HeuristicStrategyName, "")
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		err := gameUsecases.StartGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		for i := 0; i < 200; i++ {
+			game, _ := gameUsecases.GetGame(gameID)
+			botName := game.BotToPlay()
+			if botName == "" {
+				break
+			}
+
+			_, err := gameUsecases.PlayBot(gameID, botName)
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		want, _ := gameUsecases.GetGame(gameID)
+		events, _ := gameUsecases.GetEvents(gameID)
+
+		got, err := domain.Replay(events)
+
+		assert.NoError(err)
+		assert.Equal(domain.Counting, got.Phase)
+		assert.Equal(want.Players, got.Players)
+		assert.Equal(want.Bids, got.Bids)
+		assert.Equal(want.Turns, got.Turns)
+		assert.Equal(want.Declarations, got.Declarations)
+		assert.Equal(want.Points, got.Points)
+		assert.Equal(want.Scores, got.Scores)
+		assert.Equal(want.Redeals, got.Redeals)
+		assert.Equal(want.Target, got.Target)
+	})
+}
+
+func TestReconnect(test *testing.T) {
+	assert := assert.New(test)
+
+	mockRepository := NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	gameID, err := gameUsecases.CreateGame("GAME ONE", 0, "", "", 0)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	_, err = gameUsecases.JoinGame(gameID, "P1")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("should give the game back to a seated player", func(test *testing.T) {
+		game, err := gameUsecases.Reconnect(gameID, "P1")
+
+		assert.NoError(err)
+		assert.Contains(game.Players, "P1")
+	})
+
+	test.Run("should not seat an unknown player", func(test *testing.T) {
+		_, err := gameUsecases.Reconnect(gameID, "P2")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrPlayerNotFound, err.Error())
+	})
+
+	test.Run("should only give the events after a sequence", func(test *testing.T) {
+		err := gameUsecases.JoinTeam(gameID, "P1", "A Team")
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		events, err := gameUsecases.GetEventsSince(gameID, 2, "P1")
+
+		assert.NoError(err)
+		assert.Equal(1, len(events))
+		assert.Equal(domain.JoinTeamEvent, events[0].Kind)
+	})
+
+	test.Run("should give a reconnecting player no other hand than its own", func(test *testing.T) {
+		for _, name := range []string{"P2", "P3", "P4"} {
+			_, err := gameUsecases.JoinGame(gameID, name)
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+		for name, team := range map[string]string{"P2": "B Team", "P3": "A Team", "P4": "B Team"} {
+			err := gameUsecases.JoinTeam(gameID, name, team)
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+		err := gameUsecases.StartGame(gameID)
+		if err != nil {
+			test.Fatal(err)
+		}
+		game, _ := gameUsecases.GetGame(gameID)
+		err = gameUsecases.Cut(gameID, game.PlayerToAct(), 10)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		events, err := gameUsecases.GetEventsSince(gameID, 0, "P1")
+
+		assert.NoError(err)
+		cut := events[len(events)-1]
+		assert.Equal(domain.CutEvent, cut.Kind)
+		assert.Equal(8, len(cut.Payload.Hands["P1"]))
+		for _, event := range events {
+			assert.Equal(int64(0), event.Payload.Seed)
+			for name := range event.Payload.Hands {
+				assert.Equal("P1", name)
+			}
+		}
+	})
+}
+
+func TestPlayOnTimeout(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team"},
+		"P2": {Team: "B Team"},
+		"P3": {Team: "A Team"},
+		"P4": {Team: "B Team"},
+	}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	err := gameUsecases.StartGame(1)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("should not play for a player who is not expected to act", func(test *testing.T) {
+		game, _ := gameUsecases.GetGame(1)
+		for name, player := range game.Players {
+			if player.Order != 1 {
+				_, err := gameUsecases.PlayOnTimeout(1, name)
+
+				assert.Error(err)
+				assert.Equal(domain.ErrNotYourTurn, err.Error())
+				return
+			}
+		}
+	})
+
+	test.Run("should cut randomly while cutting", func(test *testing.T) {
+		game, _ := gameUsecases.GetGame(1)
+		playerName := game.PlayerToAct()
+
+		_, err := gameUsecases.PlayOnTimeout(1, playerName)
+
+		assert.NoError(err)
+		game, _ = gameUsecases.GetGame(1)
+		assert.Equal(playerName, game.PlayerToAct())
+		assert.Equal(domain.Bidding, game.Phase)
+	})
+
+	test.Run("should pass while bidding", func(test *testing.T) {
+		game, _ := gameUsecases.GetGame(1)
+		playerName := game.PlayerToAct()
+
+		hasRedealt, err := gameUsecases.PlayOnTimeout(1, playerName)
+
+		assert.NoError(err)
+		assert.False(hasRedealt)
+		game, _ = gameUsecases.GetGame(1)
+		assert.NotEqual(playerName, game.PlayerToAct())
+		assert.Equal(domain.Bidding, game.Phase)
+	})
+
+	test.Run("should play the lowest legal cards until the end of the deal", func(test *testing.T) {
+		game, _ := gameUsecases.GetGame(1)
+		err := gameUsecases.Bid(1, game.PlayerToAct(), domain.Eighty, domain.Heart)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		for i := 0; i < 40; i++ {
+			game, _ := gameUsecases.GetGame(1)
+			playerName := game.PlayerToAct()
+			if playerName == "" {
+				break
+			}
+
+			want, _ := game.LowestLegalCard(playerName)
+
+			_, err := gameUsecases.PlayOnTimeout(1, playerName)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			game, _ = gameUsecases.GetGame(1)
+			if want != "" {
+				lastTurn := game.Turns[len(game.Turns)-1]
+				assert.Equal(want, lastTurn.Plays[len(lastTurn.Plays)-1].Card)
+			}
+		}
+
+		game, _ = gameUsecases.GetGame(1)
+		assert.Equal(domain.Counting, game.Phase)
+		assert.Equal(8, len(game.Turns))
+	})
+}
+
+func TestSpectators(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{
+		"P1": {Team: "A Team"},
+		"P2": {Team: "B Team"},
+		"P3": {Team: "A Team"},
+		"P4": {Team: "B Team"},
+	}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	err := gameUsecases.StartGame(1)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Run("should observe a started game without a seat", func(test *testing.T) {
+		game, err := gameUsecases.JoinGame(1, "S1")
+
+		assert.NoError(err)
+		assert.Equal(4, len(game.Players))
+		assert.NotContains(game.Players, "S1")
+	})
+
+	test.Run("should join again a started game with a seat", func(test *testing.T) {
+		game, err := gameUsecases.JoinGame(1, "P1")
+
+		assert.NoError(err)
+		assert.Equal(4, len(game.Players))
+	})
+
+	test.Run("should watch a started game", func(test *testing.T) {
+		game, err := gameUsecases.WatchGame(1, "S1")
+
+		assert.NoError(err)
+		assert.Equal([]string{"S1"}, game.Spectators)
+
+		previews, err := gameUsecases.ListGames()
+
+		assert.NoError(err)
+		assert.Equal([]string{"S1"}, previews[0].Spectators)
+	})
+
+	test.Run("should stop watching", func(test *testing.T) {
+		game, err := gameUsecases.StopWatching(1, "S1")
+
+		assert.NoError(err)
+		assert.Equal([]string{}, game.Spectators)
+	})
+}
+
+func TestChat(test *testing.T) {
+	assert := assert.New(test)
+
+	game := domain.NewGame("GAME ONE")
+	game.Players = map[string]domain.Player{"P1": {}}
+	mockRepository := NewMockGameRepo(
+		map[int]domain.Game{1: game},
+	)
+	gameUsecases := NewGameUsecases(&mockRepository)
+
+	test.Run("should store the messages of the players", func(test *testing.T) {
+		_, err := gameUsecases.Chat(1, "P1", "hello")
+		assert.NoError(err)
+		_, err = gameUsecases.React(1, "P1", "clap")
+		assert.NoError(err)
+
+		got, err := gameUsecases.GetChatHistory(1)
+
+		assert.NoError(err)
+		assert.Equal(2, len(got))
+		assert.Equal("hello", got[0].Content)
+		assert.Equal(domain.ReactionChat, got[1].Kind)
+	})
+
+	test.Run("should not store the messages of strangers", func(test *testing.T) {
+		_, err := gameUsecases.Chat(1, "P2", "hello")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrNotInGame, err.Error())
+	})
+
+	test.Run("should only keep the last messages", func(test *testing.T) {
+		for i := 0; i < domain.CHAT_HISTORY_SIZE; i++ {
+			_, err := gameUsecases.Chat(1, "P1", fmt.Sprint("message ", i))
+			if err != nil {
+				test.Fatal(err)
+			}
+		}
+
+		got, err := gameUsecases.GetChatHistory(1)
+
+		assert.NoError(err)
+		assert.Equal(domain.CHAT_HISTORY_SIZE, len(got))
+		assert.Equal("message 0", got[0].Content)
+	})
+}
+
+// racingRepo lets another command update the game between the read and the update of the tested command.
+type racingRepo struct {
+	*MockGameRepo
+	races int
+	race  func()
+}
+
+func (repo *racingRepo) UpdateGame(game domain.Game, events ...domain.Event) error {
+	if repo.races > 0 {
+		repo.races--
+		repo.race()
+	}
+	return repo.MockGameRepo.UpdateGame(game, events...)
+}
+
+func (repo *racingRepo) ArchiveAndUpdateGame(game domain.Game, events ...domain.Event) error {
+	if repo.races > 0 {
+		repo.races--
+		repo.race()
+	}
+	return repo.MockGameRepo.ArchiveAndUpdateGame(game, events...)
+}
+
+func TestConcurrentUpdates(test *testing.T) {
+	assert := assert.New(test)
+
+	test.Run("should retry a command against the fresh game", func(test *testing.T) {
+		mockRepository := NewMockGameRepo(
+			map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+		)
+		otherUsecases := NewGameUsecases(&mockRepository)
+		repository := &racingRepo{MockGameRepo: &mockRepository, races: 1, race: func() {
+			_, err := otherUsecases.WatchGame(1, "S2")
+			assert.NoError(err)
+		}}
+		gameUsecases := NewGameUsecases(repository)
+
+		game, err := gameUsecases.WatchGame(1, "S1")
+
+		assert.NoError(err)
+		assert.Equal([]string{"S2", "S1"}, game.Spectators)
+		assert.Equal(2, game.Version)
+	})
+
+	test.Run("should refuse a stale update of a player", func(test *testing.T) {
+		mockRepository := NewMockGameRepo(
+			map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+		)
+
+		err := mockRepository.UpdatePlayer(1, 1, "P1", domain.Player{})
+
+		assert.Error(err)
+		assert.Equal(ErrVersionConflict, err.Error())
+	})
+
+	test.Run("should archive a finished deal only once when the next one is started concurrently", func(test *testing.T) {
+		game := domain.NewGame("GAME ONE")
+		game.Players = map[string]domain.Player{
+			"P1": {Team: "A Team"},
+			"P2": {Team: "B Team"},
+			"P3": {Team: "A Team"},
+			"P4": {Team: "B Team"},
+		}
+		game.Phase = domain.Counting
+		mockRepository := NewMockGameRepo(
+			map[int]domain.Game{1: game},
+		)
+		otherUsecases := NewGameUsecases(&mockRepository)
+		repository := &racingRepo{MockGameRepo: &mockRepository, races: 1, race: func() {
+			_, err := otherUsecases.WatchGame(1, "S1")
+			assert.NoError(err)
+		}}
+		gameUsecases := NewGameUsecases(repository)
+
+		err := gameUsecases.StartGame(1)
+
+		assert.NoError(err)
+		assert.Equal(2, len(mockRepository.games))
+		archive := mockRepository.games[2]
+		assert.Equal(1, archive.Root)
+		assert.Equal(domain.Counting, archive.Phase)
+		assert.Equal([]string{"S1"}, archive.Spectators)
+	})
+
+	test.Run("should give up after too many conflicts", func(test *testing.T) {
+		mockRepository := NewMockGameRepo(
+			map[int]domain.Game{1: domain.NewGame("GAME ONE")},
+		)
+		otherUsecases := NewGameUsecases(&mockRepository)
+		races := 0
+		repository := &racingRepo{MockGameRepo: &mockRepository, races: MAX_UPDATE_ATTEMPTS, race: func() {
+			races++
+			_, err := otherUsecases.WatchGame(1, fmt.Sprint("S", races+1))
+			assert.NoError(err)
+		}}
+		gameUsecases := NewGameUsecases(repository)
+
+		_, err := gameUsecases.WatchGame(1, "S1")
+
+		assert.Error(err)
+		assert.Equal(ErrVersionConflict, err.Error())
+		assert.Equal(MAX_UPDATE_ATTEMPTS, races)
+	})
+}
@@ -2,14 +2,42 @@ package usecases
 
 import (
 	"coinche/domain"
+	"encoding/json"
 	"errors"
+	"time"
 )
 
 type MockGameRepo struct {
 	games         map[int]domain.Game
+	events        map[int][]domain.Event
+	chats         map[int][]domain.ChatMessage
 	creationCalls int
 }
 
+func (repo *MockGameRepo) AddChatMessage(gameID int, message domain.ChatMessage) error {
+	messages := append(repo.chats[gameID], message)
+	if len(messages) > domain.CHAT_HISTORY_SIZE {
+		messages = messages[len(messages)-domain.CHAT_HISTORY_SIZE:]
+	}
+	repo.chats[gameID] = messages
+	return nil
+}
+
+func (repo *MockGameRepo) GetChatMessages(gameID int) ([]domain.ChatMessage, error) {
+	return append([]domain.ChatMessage{}, repo.chats[gameID]...), nil
+}
+
+func (repo *MockGameRepo) appendEvents(gameID int, events []domain.Event) {
+	for _, event := range events {
+		event.Sequence = len(repo.events[gameID]) + 1
+		repo.events[gameID] = append(repo.events[gameID], event)
+	}
+}
+
+func (repo *MockGameRepo) GetEvents(gameID int) ([]domain.Event, error) {
+	return append([]domain.Event{}, repo.events[gameID]...), nil
+}
+
 func (repo *MockGameRepo) ListGames() ([]domain.Game, error) {
 	var games []domain.Game
 	for gameID, val := range repo.games {
@@ -23,13 +51,13 @@ func (repo *MockGameRepo) GetGame(gameID int) (domain.Game, error) {
 	game, ok := repo.games[gameID]
 	game.ID = gameID
 	if !ok {
-		return domain.Game{}, errors.New("GAME NOT FOUND")
+		return domain.Game{}, errors.New(ErrGameNotFound)
 	}
 
 	return game, nil
 }
 
-func (repo *MockGameRepo) CreateGame(game domain.Game) (int, error) {
+func (repo *MockGameRepo) CreateGame(game domain.Game, events ...domain.Event) (int, error) {
 	var gameID int
 	if game.ID == 0 {
 		gameID = len(repo.games) + 1
@@ -41,25 +69,35 @@ func (repo *MockGameRepo) CreateGame(game domain.Game) (int, error) {
 
 	repo.creationCalls = repo.creationCalls + 1
 	repo.games[gameID] = game
+	repo.appendEvents(gameID, events)
 	return gameID, nil
 }
 
-func (repo *MockGameRepo) UpdatePlayer(gameID int, playerName string, player domain.Player) error {
+func (repo *MockGameRepo) UpdatePlayer(gameID int, version int, playerName string, player domain.Player, events ...domain.Event) error {
 	game, ok := repo.games[gameID]
 	if !ok {
-		return errors.New("GAME NOT FOUND")
+		return errors.New(ErrGameNotFound)
+	}
+	if game.Version != version {
+		return errors.New(ErrVersionConflict)
 	}
+	game.Version++
 	game.Players[playerName] = player
 	repo.games[gameID] = game
+	repo.appendEvents(gameID, events)
 	return nil
 }
 
-func (repo *MockGameRepo) UpdateGame(game domain.Game) error {
+func (repo *MockGameRepo) UpdateGame(game domain.Game, events ...domain.Event) error {
 	repoGame, ok := repo.games[game.ID]
 	if !ok {
-		return errors.New("GAME NOT FOUND")
+		return errors.New(ErrGameNotFound)
 
 	}
+	if repoGame.Version != game.Version {
+		return errors.New(ErrVersionConflict)
+	}
+	repoGame.Version++
 	repoGame.Phase = game.Phase
 	repoGame.Bids = game.Bids
 	repoGame.Deck = game.Deck
@@ -68,13 +106,50 @@ func (repo *MockGameRepo) UpdateGame(game domain.Game) error {
 	repoGame.Points = game.Points
 	repoGame.Scores = game.Scores
 	repoGame.Root = game.Root
+	repoGame.Redeals = game.Redeals
+	repoGame.Target = game.Target
+	repoGame.Winner = game.Winner
+	repoGame.Declarations = game.Declarations
+	repoGame.Rules = game.Rules
+	repoGame.Spectators = game.Spectators
+	repoGame.Dealer = game.Dealer
+	repoGame.Draws = game.Draws
 
 	repo.games[game.ID] = repoGame
+	repo.appendEvents(game.ID, events)
 	return nil
 }
 
+func (repo *MockGameRepo) ArchiveAndUpdateGame(game domain.Game, events ...domain.Event) error {
+	stored, ok := repo.games[game.ID]
+	if !ok {
+		return errors.New(ErrGameNotFound)
+	}
+	if stored.Version != game.Version {
+		return errors.New(ErrVersionConflict)
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	var archive domain.Game
+	err = json.Unmarshal(data, &archive)
+	if err != nil {
+		return err
+	}
+
+	archive.ID = len(repo.games) + 1
+	archive.Version = 0
+	repo.games[archive.ID] = archive
+
+	return repo.UpdateGame(game, events...)
+}
+
 func (repo *MockGameRepo) DeleteGame(gameID int) error {
 	delete(repo.games, gameID)
+	delete(repo.events, gameID)
+	delete(repo.chats, gameID)
 	return nil
 }
 
@@ -85,6 +160,74 @@ func NewMockGameRepo(games map[int]domain.Game) MockGameRepo {
 	}
 	return MockGameRepo{
 		games:         games,
+		events:        map[int][]domain.Event{},
+		chats:         map[int][]domain.ChatMessage{},
 		creationCalls: 0,
 	}
 }
+
+type MockUserRepo struct {
+	users map[string]domain.User
+}
+
+func (repo *MockUserRepo) CreateUser(user domain.User) (int, error) {
+	if _, ok := repo.users[user.Name]; ok {
+		return 0, errors.New(domain.ErrUserAlreadyExists)
+	}
+
+	user.ID = len(repo.users) + 1
+	user.CreatedAt = time.Now()
+	repo.users[user.Name] = user
+	return user.ID, nil
+}
+
+func (repo *MockUserRepo) GetUser(name string) (domain.User, error) {
+	user, ok := repo.users[name]
+	if !ok {
+		return domain.User{}, errors.New(domain.ErrUserNotFound)
+	}
+	return user, nil
+}
+
+func NewMockUserRepo() MockUserRepo {
+	return MockUserRepo{users: map[string]domain.User{}}
+}
+
+type MockTournamentRepo struct {
+	tournaments map[int]domain.Tournament
+}
+
+func (repo *MockTournamentRepo) ListTournaments() ([]domain.Tournament, error) {
+	tournaments := []domain.Tournament{}
+	for i := 1; i <= len(repo.tournaments); i++ {
+		tournaments = append(tournaments, repo.tournaments[i])
+	}
+	return tournaments, nil
+}
+
+func (repo *MockTournamentRepo) CreateTournament(tournament domain.Tournament) (int, error) {
+	tournament.ID = len(repo.tournaments) + 1
+	tournament.CreatedAt = time.Now()
+	repo.tournaments[tournament.ID] = tournament
+	return tournament.ID, nil
+}
+
+func (repo *MockTournamentRepo) GetTournament(tournamentID int) (domain.Tournament, error) {
+	tournament, ok := repo.tournaments[tournamentID]
+	if !ok {
+		return domain.Tournament{}, errors.New(ErrTournamentNotFound)
+	}
+	return tournament, nil
+}
+
+func (repo *MockTournamentRepo) UpdateTournament(tournament domain.Tournament) error {
+	if _, ok := repo.tournaments[tournament.ID]; !ok {
+		return errors.New(ErrTournamentNotFound)
+	}
+	repo.tournaments[tournament.ID] = tournament
+	return nil
+}
+
+func NewMockTournamentRepo() MockTournamentRepo {
+	return MockTournamentRepo{tournaments: map[int]domain.Tournament{}}
+}
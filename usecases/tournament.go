@@ -0,0 +1,300 @@
+package usecases
+
+import (
+	"coinche/domain"
+	"errors"
+	"sync"
+)
+
+const (
+	ErrTournamentNotFound = "TOURNAMENT NOT FOUND"
+)
+
+type TournamentRepositoryInterface interface {
+	ListTournaments() ([]domain.Tournament, error)
+	CreateTournament(tournament domain.Tournament) (int, error)
+	GetTournament(tournamentID int) (domain.Tournament, error)
+	UpdateTournament(tournament domain.Tournament) error
+}
+
+// TournamentUsecases runs one command at a time, so a round is never started twice by the end of two matches.
+type TournamentUsecases struct {
+	Repo  TournamentRepositoryInterface
+	Games *GameUsecases
+	mu    sync.Mutex
+}
+
+// NewTournamentUsecases also gives the games the entrants of the tournaments, so they can take the seats of their
+// matches.
+func NewTournamentUsecases(repository TournamentRepositoryInterface, games *GameUsecases) *TournamentUsecases {
+	tournamentUsecases := &TournamentUsecases{Repo: repository, Games: games}
+	games.Entrants = tournamentUsecases
+	return tournamentUsecases
+}
+
+func (s *TournamentUsecases) ListTournaments() ([]domain.Tournament, error) {
+	return s.Repo.ListTournaments()
+}
+
+// CreateTournament draws a new seed when none is given, the seed giving the boards of every round in duplicate.
+func (s *TournamentUsecases) CreateTournament(name string, format domain.TournamentFormat, rules string, target int, seed int64) (int, error) {
+	if seed == 0 {
+		seed = domain.NewSeed()
+	}
+
+	tournament, err := domain.NewTournament(name, format, rules, target, seed)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Repo.CreateTournament(tournament)
+}
+
+func (s *TournamentUsecases) GetTournament(tournamentID int) (domain.Tournament, error) {
+	return s.Repo.GetTournament(tournamentID)
+}
+
+func (s *TournamentUsecases) AddPair(tournamentID int, name string, players []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, err := s.Repo.GetTournament(tournamentID)
+	if err != nil {
+		return err
+	}
+
+	err = tournament.AddPair(name, players)
+	if err != nil {
+		return err
+	}
+
+	return s.Repo.UpdateTournament(tournament)
+}
+
+func (s *TournamentUsecases) getTableGames(tournament domain.Tournament) (map[int]domain.Game, error) {
+	games := map[int]domain.Game{}
+	for _, table := range tournament.Tables {
+		if table.GameID == 0 {
+			continue
+		}
+
+		game, err := s.Games.GetGame(table.GameID)
+		if err != nil {
+			return nil, err
+		}
+		games[table.GameID] = game
+	}
+	return games, nil
+}
+
+// createBoard creates the board already dealt, with the events which create and deal it, so it can be replayed.
+func (s *TournamentUsecases) createBoard(tournament domain.Tournament, table domain.Table) (int, error) {
+	game, events, err := tournament.NewBoard(table)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Games.Repo.CreateGame(game, events...)
+}
+
+// createMatch seats the players of both pairs in their team, so they only have to join the game to start it.
+func (s *TournamentUsecases) createMatch(tournament domain.Tournament, table domain.Table) (int, error) {
+	gameID, err := s.Games.CreateGame(tournament.GetMatchName(table), tournament.Target, tournament.Rules, "", 0)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pairName := range []string{table.NorthSouth, table.EastWest} {
+		pair, ok := tournament.GetPair(pairName)
+		if !ok {
+			return 0, errors.New(domain.ErrUnknownPair)
+		}
+
+		for _, player := range pair.Players {
+			_, err = s.Games.JoinGame(gameID, player)
+			if err != nil {
+				return 0, err
+			}
+
+			err = s.Games.JoinTeam(gameID, player, pair.Name)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return gameID, nil
+}
+
+// startRound creates the games of the next round, a pair without opponent in a knockout round having no game.
+func (s *TournamentUsecases) startRound(tournament *domain.Tournament, games map[int]domain.Game) error {
+	round, err := tournament.NextRound(games)
+	if err != nil {
+		return err
+	}
+
+	for i, table := range tournament.Tables {
+		if table.Round != round || table.EastWest == "" {
+			continue
+		}
+
+		var gameID int
+		if tournament.IsDuplicate() {
+			gameID, err = s.createBoard(*tournament, table)
+		} else {
+			gameID, err = s.createMatch(*tournament, table)
+		}
+		if err != nil {
+			return err
+		}
+		tournament.Tables[i].GameID = gameID
+	}
+
+	return nil
+}
+
+// StartRound creates the games of the next round, once the previous one is finished.
+func (s *TournamentUsecases) StartRound(tournamentID int) (domain.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, err := s.Repo.GetTournament(tournamentID)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	games, err := s.getTableGames(tournament)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	err = s.startRound(&tournament, games)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	err = s.Repo.UpdateTournament(tournament)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	return tournament, nil
+}
+
+func (s *TournamentUsecases) getTournamentOfGame(gameID int) (domain.Tournament, error) {
+	tournaments, err := s.Repo.ListTournaments()
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	for _, tournament := range tournaments {
+		for _, table := range tournament.Tables {
+			if table.GameID == gameID {
+				return tournament, nil
+			}
+		}
+	}
+
+	return domain.Tournament{}, errors.New(ErrTournamentNotFound)
+}
+
+// AdvanceTournament records the winner of the finished game in its tournament, then starts the next round of a round
+// robin or a knockout once every match of the round is finished. The rounds of a duplicate are started by hand.
+func (s *TournamentUsecases) AdvanceTournament(gameID int) (domain.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, err := s.getTournamentOfGame(gameID)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	games, err := s.getTableGames(tournament)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	tournament.RecordWinners(games)
+
+	if !tournament.IsDuplicate() && !tournament.IsFinished(games) {
+		err = s.startRound(&tournament, games)
+		if err != nil && err.Error() != domain.ErrRoundNotFinished {
+			return domain.Tournament{}, err
+		}
+	}
+
+	err = s.Repo.UpdateTournament(tournament)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	return tournament, nil
+}
+
+// IsEntrant checks the player against the pairs registered for the table of the game, a game outside of the
+// tournaments having no entrant.
+func (s *TournamentUsecases) IsEntrant(gameID int, playerName string) (bool, error) {
+	tournament, err := s.getTournamentOfGame(gameID)
+	if err != nil && err.Error() == ErrTournamentNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, table := range tournament.Tables {
+		if table.GameID != gameID {
+			continue
+		}
+
+		for _, pairName := range []string{table.NorthSouth, table.EastWest} {
+			pair, ok := tournament.GetPair(pairName)
+			if !ok {
+				continue
+			}
+			for _, player := range pair.Players {
+				if player == playerName {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// CheckGameVisible hides a game of a tournament until every table of its round is finished, as the other tables of a
+// duplicate round play the same board.
+func (s *TournamentUsecases) CheckGameVisible(gameID int) error {
+	tournament, err := s.getTournamentOfGame(gameID)
+	if err != nil && err.Error() == ErrTournamentNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	games, err := s.getTableGames(tournament)
+	if err != nil {
+		return err
+	}
+
+	if !tournament.IsRoundOfGameFinished(gameID, games) {
+		return errors.New(domain.ErrRoundNotFinished)
+	}
+	return nil
+}
+
+func (s *TournamentUsecases) GetStandings(tournamentID int) ([]domain.Standing, error) {
+	tournament, err := s.Repo.GetTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.getTableGames(tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	return tournament.GetStandings(games), nil
+}
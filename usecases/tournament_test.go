@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"coinche/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournaments(test *testing.T) {
+	assert := assert.New(test)
+	gameRepository := NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := NewGameUsecases(&gameRepository)
+	tournamentRepository := NewMockTournamentRepo()
+	tournamentUsecases := NewTournamentUsecases(&tournamentRepository, gameUsecases)
+
+	tournamentID := 0
+
+	test.Run("should create a tournament with the given seed", func(test *testing.T) {
+		var err error
+		tournamentID, err = tournamentUsecases.CreateTournament("CUP", "", "", 0, 42)
+		assert.NoError(err)
+
+		got, err := tournamentUsecases.GetTournament(tournamentID)
+
+		assert.NoError(err)
+		assert.Equal("CUP", got.Name)
+		assert.Equal(int64(42), got.Seed)
+	})
+
+	test.Run("should fail with an unknown tournament", func(test *testing.T) {
+		err := tournamentUsecases.AddPair(1000, "A", []string{"A1", "A2"})
+
+		assert.Error(err)
+		assert.Equal(ErrTournamentNotFound, err.Error())
+	})
+
+	test.Run("should fail to start without enough pairs", func(test *testing.T) {
+		_, err := tournamentUsecases.StartRound(tournamentID)
+
+		assert.Error(err)
+		assert.Equal(domain.ErrNotEnoughPairs, err.Error())
+	})
+
+	test.Run("should register the pairs", func(test *testing.T) {
+		for _, name := range []string{"A", "B", "C", "D"} {
+			err := tournamentUsecases.AddPair(tournamentID, name, []string{name + "1", name + "2"})
+			assert.NoError(err)
+		}
+
+		got, _ := tournamentUsecases.GetTournament(tournamentID)
+
+		assert.Equal(4, len(got.Pairs))
+	})
+
+	test.Run("should create the boards of the first round", func(test *testing.T) {
+		got, err := tournamentUsecases.StartRound(tournamentID)
+		assert.NoError(err)
+		assert.Equal(2, len(got.Tables))
+
+		first, err := gameUsecases.GetGame(got.Tables[0].GameID)
+		assert.NoError(err)
+		second, err := gameUsecases.GetGame(got.Tables[1].GameID)
+		assert.NoError(err)
+
+		assert.NotEqual(first.ID, second.ID)
+		assert.Equal(domain.Bidding, first.Phase)
+		assert.Equal(first.Players["A1"].Hand, second.Players["B1"].Hand)
+	})
+
+	test.Run("should record the events which create and deal the boards", func(test *testing.T) {
+		got, _ := tournamentUsecases.GetTournament(tournamentID)
+		gameID := got.Tables[0].GameID
+		game, _ := gameUsecases.GetGame(gameID)
+
+		events, err := gameUsecases.GetEvents(gameID)
+		assert.NoError(err)
+		assert.Equal(2, len(events))
+		assert.NotEqual(int64(0), events[0].Payload.Seed)
+		assert.Equal(game.Dealer, events[1].Payload.Dealer)
+		assert.Equal(game.Hands(), events[1].Payload.Hands)
+
+		replayed, err := domain.Replay(events)
+		assert.NoError(err)
+		assert.Equal(game.Players, replayed.Players)
+		assert.Equal(game.Rules, replayed.Rules)
+		assert.Equal(game.PlayerToAct(), replayed.PlayerToAct())
+	})
+
+	test.Run("should not start the next round before the boards are finished", func(test *testing.T) {
+		_, err := tournamentUsecases.StartRound(tournamentID)
+
+		assert.Error(err)
+		assert.Equal(domain.ErrRoundNotFinished, err.Error())
+	})
+
+	test.Run("should give the standings of the finished boards", func(test *testing.T) {
+		tournament, _ := tournamentUsecases.GetTournament(tournamentID)
+		for _, table := range tournament.Tables {
+			game, _ := gameUsecases.GetGame(table.GameID)
+			for i := 0; i < 4; i++ {
+				_, err := gameUsecases.Pass(table.GameID, game.PlayerToAct())
+				assert.NoError(err)
+				game, _ = gameUsecases.GetGame(table.GameID)
+			}
+			assert.Equal(domain.Finished, game.Phase)
+		}
+
+		got, err := tournamentUsecases.GetStandings(tournamentID)
+
+		assert.NoError(err)
+		assert.Equal([]domain.Standing{
+			{Pair: "A", MatchPoints: 1, Boards: 1},
+			{Pair: "B", MatchPoints: 1, Boards: 1},
+			{Pair: "C", MatchPoints: 1, Boards: 1},
+			{Pair: "D", MatchPoints: 1, Boards: 1},
+		}, got)
+	})
+
+	test.Run("should start the next round once the boards are finished", func(test *testing.T) {
+		got, err := tournamentUsecases.StartRound(tournamentID)
+
+		assert.NoError(err)
+		assert.Equal(4, len(got.Tables))
+		assert.Equal(2, got.CurrentRound())
+	})
+}
+
+func finishMatchOrFatal(test *testing.T, gameUsecases *GameUsecases, gameID int, winner string) {
+	game, err := gameUsecases.GetGame(gameID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	game.Phase = domain.Finished
+	game.Winner = winner
+	game.Scores = map[string]int{winner: 1000}
+
+	err = gameUsecases.Repo.UpdateGame(game)
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+func TestKnockoutTournaments(test *testing.T) {
+	assert := assert.New(test)
+	gameRepository := NewMockGameRepo(map[int]domain.Game{})
+	gameUsecases := NewGameUsecases(&gameRepository)
+	tournamentRepository := NewMockTournamentRepo()
+	tournamentUsecases := NewTournamentUsecases(&tournamentRepository, gameUsecases)
+
+	tournamentID, err := tournamentUsecases.CreateTournament("CUP", domain.KnockoutFormat, "", 1500, 42)
+	if err != nil {
+		test.Fatal(err)
+	}
+	for _, name := range []string{"A", "B", "C"} {
+		err = tournamentUsecases.AddPair(tournamentID, name, []string{name + "1", name + "2"})
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	test.Run("should create the matches with the teams already assigned", func(test *testing.T) {
+		got, err := tournamentUsecases.StartRound(tournamentID)
+		assert.NoError(err)
+		assert.Equal(2, len(got.Tables))
+		assert.Equal(0, got.Tables[1].GameID)
+
+		game, err := gameUsecases.GetGame(got.Tables[0].GameID)
+		assert.NoError(err)
+
+		assert.Equal(domain.Teaming, game.Phase)
+		assert.Equal(1500, game.Target)
+		assert.Equal("A", game.Players["A1"].Team)
+		assert.Equal("A", game.Players["A2"].Team)
+		assert.Equal("B", game.Players["B1"].Team)
+		assert.Equal("B", game.Players["B2"].Team)
+	})
+
+	test.Run("should let a seated player join its match", func(test *testing.T) {
+		tournament, _ := tournamentUsecases.GetTournament(tournamentID)
+
+		game, err := gameUsecases.JoinGame(tournament.Tables[0].GameID, "A1")
+
+		assert.NoError(err)
+		assert.Equal(4, len(game.Players))
+	})
+
+	test.Run("should not let a player claim a seat outside of the tournaments", func(test *testing.T) {
+		gameID, _ := gameUsecases.CreateGame("GAME ONE", 0, "", "", 0)
+		_, _ = gameUsecases.JoinGame(gameID, "P1")
+		_ = gameUsecases.JoinTeam(gameID, "P1", "A Team")
+
+		_, err := gameUsecases.JoinGame(gameID, "P1")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrAlreadyInGame, err.Error())
+	})
+
+	test.Run("should not advance before the match is finished", func(test *testing.T) {
+		tournament, _ := tournamentUsecases.GetTournament(tournamentID)
+
+		got, err := tournamentUsecases.AdvanceTournament(tournament.Tables[0].GameID)
+
+		assert.NoError(err)
+		assert.Equal(1, got.CurrentRound())
+	})
+
+	test.Run("should advance the winner once its match is finished", func(test *testing.T) {
+		tournament, _ := tournamentUsecases.GetTournament(tournamentID)
+		finishMatchOrFatal(test, gameUsecases, tournament.Tables[0].GameID, "B")
+
+		got, err := tournamentUsecases.AdvanceTournament(tournament.Tables[0].GameID)
+
+		assert.NoError(err)
+		assert.Equal("B", got.Tables[0].Winner)
+		assert.Equal(domain.Table{Round: 2, Number: 1, NorthSouth: "B", EastWest: "C", GameID: got.Tables[2].GameID}, got.Tables[2])
+
+		game, err := gameUsecases.GetGame(got.Tables[2].GameID)
+		assert.NoError(err)
+		assert.Equal("C", game.Players["C1"].Team)
+	})
+
+	test.Run("should finish the tournament with the final", func(test *testing.T) {
+		tournament, _ := tournamentUsecases.GetTournament(tournamentID)
+		finishMatchOrFatal(test, gameUsecases, tournament.Tables[2].GameID, "C")
+
+		got, err := tournamentUsecases.AdvanceTournament(tournament.Tables[2].GameID)
+		assert.NoError(err)
+		assert.Equal(3, len(got.Tables))
+		assert.Equal("C", got.Tables[2].Winner)
+
+		standings, err := tournamentUsecases.GetStandings(tournamentID)
+		assert.NoError(err)
+		assert.Equal("C", standings[0].Pair)
+		assert.Equal(4, standings[0].MatchPoints)
+	})
+
+	test.Run("should fail with a game outside of the tournaments", func(test *testing.T) {
+		gameID, _ := gameUsecases.CreateGame("GAME ONE", 0, "", "", 0)
+
+		_, err := tournamentUsecases.AdvanceTournament(gameID)
+
+		assert.Error(err)
+		assert.Equal(ErrTournamentNotFound, err.Error())
+	})
+}
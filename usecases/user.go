@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"coinche/auth"
+	"coinche/domain"
+	"errors"
+	"time"
+)
+
+const TOKEN_DURATION = 7 * 24 * time.Hour
+
+type UserRepositoryInterface interface {
+	CreateUser(user domain.User) (int, error)
+	GetUser(name string) (domain.User, error)
+}
+
+type UserUsecases struct {
+	Repo          UserRepositoryInterface
+	secret        []byte
+	tokenDuration time.Duration
+}
+
+func NewUserUsecases(repository UserRepositoryInterface, secret []byte) (*UserUsecases, error) {
+	err := auth.CheckSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserUsecases{Repo: repository, secret: secret, tokenDuration: TOKEN_DURATION}, nil
+}
+
+func (s *UserUsecases) IssueToken(name string) (string, error) {
+	return auth.NewToken(name, s.secret, s.tokenDuration)
+}
+
+func (s *UserUsecases) Register(name string, password string) (string, error) {
+	err := domain.CheckCredentials(name, password)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.Repo.GetUser(name)
+	if err == nil {
+		return "", errors.New(domain.ErrUserAlreadyExists)
+	}
+	if err.Error() != domain.ErrUserNotFound {
+		return "", err
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.Repo.CreateUser(domain.User{Name: name, PasswordHash: hash})
+	if err != nil {
+		return "", err
+	}
+
+	return s.IssueToken(name)
+}
+
+func (s *UserUsecases) Login(name string, password string) (string, error) {
+	user, err := s.Repo.GetUser(name)
+	if err != nil {
+		if err.Error() == domain.ErrUserNotFound {
+			return "", errors.New(domain.ErrInvalidCredentials)
+		}
+		return "", err
+	}
+
+	if !auth.CheckPassword(user.PasswordHash, password) {
+		return "", errors.New(domain.ErrInvalidCredentials)
+	}
+
+	return s.IssueToken(name)
+}
+
+func (s *UserUsecases) IssueSessionToken(gameID int, playerName string) (string, error) {
+	return auth.NewSessionToken(gameID, playerName, s.secret, s.tokenDuration)
+}
+
+// ResumeSession returns the game and the name of the player the session token has been issued for.
+func (s *UserUsecases) ResumeSession(token string) (int, string, error) {
+	return auth.ParseSessionToken(token, s.secret)
+}
+
+// Authenticate returns the name of the player the token has been issued for.
+func (s *UserUsecases) Authenticate(token string) (string, error) {
+	return auth.ParseToken(token, s.secret)
+}
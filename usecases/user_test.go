@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"coinche/auth"
+	"coinche/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecret = []byte("a secret long enough to sign the tokens")
+
+func newUserUsecasesOrFatal(test *testing.T, repository UserRepositoryInterface, secret []byte) *UserUsecases {
+	userUsecases, err := NewUserUsecases(repository, secret)
+	if err != nil {
+		test.Fatal(err)
+	}
+	return userUsecases
+}
+
+func TestUsers(test *testing.T) {
+	assert := assert.New(test)
+	mockRepository := NewMockUserRepo()
+	userUsecases := newUserUsecasesOrFatal(test, &mockRepository, testSecret)
+
+	test.Run("should refuse a secret too short to sign the tokens", func(test *testing.T) {
+		for _, secret := range []string{"", "secret"} {
+			_, err := NewUserUsecases(&mockRepository, []byte(secret))
+
+			assert.Error(err)
+			assert.Equal(auth.ErrWeakSecret, err.Error())
+		}
+	})
+
+	test.Run("should register a user and give a token for its name", func(test *testing.T) {
+		token, err := userUsecases.Register("P1", "password")
+		assert.NoError(err)
+
+		name, err := userUsecases.Authenticate(token)
+
+		assert.NoError(err)
+		assert.Equal("P1", name)
+		assert.NotEqual("password", mockRepository.users["P1"].PasswordHash)
+	})
+
+	test.Run("should not register a name twice", func(test *testing.T) {
+		_, err := userUsecases.Register("P1", "other password")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrUserAlreadyExists, err.Error())
+	})
+
+	test.Run("should not register a short password", func(test *testing.T) {
+		_, err := userUsecases.Register("P2", "short")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrPasswordTooShort, err.Error())
+	})
+
+	test.Run("should not register the name of a bot", func(test *testing.T) {
+		_, err := userUsecases.Register("Bot 1", "password")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrReservedName, err.Error())
+	})
+
+	test.Run("should login with the right password", func(test *testing.T) {
+		token, err := userUsecases.Login("P1", "password")
+		assert.NoError(err)
+
+		name, err := userUsecases.Authenticate(token)
+
+		assert.NoError(err)
+		assert.Equal("P1", name)
+	})
+
+	test.Run("should not login with a wrong password", func(test *testing.T) {
+		_, err := userUsecases.Login("P1", "wrong password")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrInvalidCredentials, err.Error())
+	})
+
+	test.Run("should not login an unknown user", func(test *testing.T) {
+		_, err := userUsecases.Login("P3", "password")
+
+		assert.Error(err)
+		assert.Equal(domain.ErrInvalidCredentials, err.Error())
+	})
+
+	test.Run("should not authenticate a token signed with another secret", func(test *testing.T) {
+		otherUsecases := newUserUsecasesOrFatal(test, &mockRepository, []byte("another secret long enough to sign the tokens"))
+		token, err := otherUsecases.IssueToken("P1")
+		assert.NoError(err)
+
+		_, err = userUsecases.Authenticate(token)
+
+		assert.Error(err)
+	})
+}
+
+func TestSessions(test *testing.T) {
+	assert := assert.New(test)
+	mockRepository := NewMockUserRepo()
+	userUsecases := newUserUsecasesOrFatal(test, &mockRepository, testSecret)
+
+	test.Run("should resume the session of a seat", func(test *testing.T) {
+		token, err := userUsecases.IssueSessionToken(3, "P1")
+		assert.NoError(err)
+
+		gameID, name, err := userUsecases.ResumeSession(token)
+
+		assert.NoError(err)
+		assert.Equal(3, gameID)
+		assert.Equal("P1", name)
+	})
+
+	test.Run("should not authenticate with a session token", func(test *testing.T) {
+		token, err := userUsecases.IssueSessionToken(3, "P1")
+		assert.NoError(err)
+
+		_, err = userUsecases.Authenticate(token)
+
+		assert.Error(err)
+	})
+}
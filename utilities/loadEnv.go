@@ -2,6 +2,9 @@ package utilities
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,3 +19,18 @@ func LoadEnv(path string) {
 		fmt.Println("Error loading .env file")
 	}
 }
+
+// GetEnvSeconds reads a number of seconds, an unset variable giving a zero duration.
+func GetEnvSeconds(key string) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -29,11 +29,34 @@ func NewGetGameRequest(test *testing.T, gameID int) *http.Request {
 	return GetNewRequest(test, route, http.MethodGet)
 }
 
-func NewJoinGameRequest(test *testing.T, gameID int, playerName string) *http.Request {
-	route := fmt.Sprintf("/games/%d/join?playerName=%s", gameID, url.QueryEscape(playerName))
+func NewGetReplayRequest(test *testing.T, gameID int) *http.Request {
+	route := fmt.Sprintf("/games/%d/replay", gameID)
 	return GetNewRequest(test, route, http.MethodGet)
 }
 
+func NewJoinGameRequest(test *testing.T, gameID int, token string) *http.Request {
+	route := fmt.Sprintf("/games/%d/join?token=%s", gameID, url.QueryEscape(token))
+	return GetNewRequest(test, route, http.MethodGet)
+}
+
+func NewCredentialsRequest(test *testing.T, route string, name string, password string) *http.Request {
+	body, err := json.Marshal(map[string]string{"name": name, "password": password})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, route, bytes.NewReader(body))
+	if err != nil {
+		test.Fatal(err)
+	}
+	return request
+}
+
+func Authorize(request *http.Request, token string) *http.Request {
+	request.Header.Set("Authorization", "Bearer "+token)
+	return request
+}
+
 func GetNewRequest(test *testing.T, route string, method string) *http.Request {
 	request, err := http.NewRequest(method, route, nil)
 	if err != nil {